@@ -1,8 +1,14 @@
 package tencentcloud
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	sdkErrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
 
@@ -224,3 +230,45 @@ desc: this is a description`
 	assert.Equalf(t, reflect.TypeOf(yaml1).String(), "map[interface {}]interface {}", "")
 	assert.Equalf(t, yaml1["name"], "test-name", "")
 }
+
+func TestAddResultOutputMetadata(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "result.json")
+	start := time.Now()
+	assert.Equalf(t, writeToFile(filePath, []map[string]interface{}{{"id": "ins-xxxxxxxx"}}), nil, "")
+	assert.Equalf(t, addResultOutputMetadata(filePath, start), nil, "")
+
+	content, err := ioutil.ReadFile(filePath)
+	assert.Equalf(t, err, nil, "")
+	var result map[string]interface{}
+	assert.Equalf(t, json.Unmarshal(content, &result), nil, "")
+	assert.Equalf(t, result["version"], RESULT_OUTPUT_METADATA_VERSION, "")
+	assert.Equalf(t, result["timestamp"] != "", true, "")
+	assert.Equalf(t, result["data"], []interface{}{map[string]interface{}{"id": "ins-xxxxxxxx"}}, "")
+
+	// a file written before the read is left untouched
+	assert.Equalf(t, addResultOutputMetadata(filePath, time.Now().Add(time.Hour)), nil, "")
+	unchanged, _ := ioutil.ReadFile(filePath)
+	assert.Equalf(t, string(unchanged), string(content), "")
+
+	// a missing file is skipped
+	assert.Equalf(t, addResultOutputMetadata(filepath.Join(t.TempDir(), "missing.json"), start), nil, "")
+}
+
+func TestWithResultOutputMetadata(t *testing.T) {
+	dataSource := &schema.Resource{
+		Read: func(d *schema.ResourceData, meta interface{}) error { return nil },
+		Schema: map[string]*schema.Schema{
+			"result_output_file": {Type: schema.TypeString, Optional: true},
+		},
+	}
+	withResultOutputMetadata(dataSource)
+	assert.Equalf(t, dataSource.Schema["include_metadata"] != nil, true, "")
+	assert.Equalf(t, dataSource.Schema["include_metadata"].Default, false, "")
+
+	withoutOutput := &schema.Resource{
+		Read:   func(d *schema.ResourceData, meta interface{}) error { return nil },
+		Schema: map[string]*schema.Schema{},
+	}
+	withResultOutputMetadata(withoutOutput)
+	assert.Equalf(t, withoutOutput.Schema["include_metadata"] == nil, true, "")
+}
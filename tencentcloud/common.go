@@ -33,6 +33,11 @@ type contextLogId string
 
 const logIdKey = contextLogId("logId")
 
+type contextJustCreated string
+
+// justCreatedKey marks a context whose read follows a create, so not-found can be tolerated for a short while
+const justCreatedKey = contextJustCreated("justCreated")
+
 const (
 	PROVIDER_READ_RETRY_TIMEOUT  = "TENCENTCLOUD_READ_RETRY_TIMEOUT"
 	PROVIDER_WRITE_RETRY_TIMEOUT = "TENCENTCLOUD_WRITE_RETRY_TIMEOUT"
@@ -346,6 +351,75 @@ func writeToFile(filePath string, data interface{}) error {
 	return ioutil.WriteFile(filePath, jsonStr, 0422)
 }
 
+// RESULT_OUTPUT_METADATA_VERSION is the version of the `result_output_file` layout written with `include_metadata`,
+// it is bumped only when the layout of the wrapper changes.
+const RESULT_OUTPUT_METADATA_VERSION = "1"
+
+// withResultOutputMetadata adds `include_metadata` to a data source supporting `result_output_file`,
+// once enabled the written file is wrapped with the layout version and the time it is written.
+func withResultOutputMetadata(dataSource *schema.Resource) {
+	if _, ok := dataSource.Schema["result_output_file"]; !ok || dataSource.Read == nil {
+		return
+	}
+	if _, ok := dataSource.Schema["include_metadata"]; ok {
+		return
+	}
+
+	dataSource.Schema["include_metadata"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether to write `result_output_file` with the metadata `version` and `timestamp`, and the results in `data`. Default is `false`.",
+	}
+
+	read := dataSource.Read
+	dataSource.Read = func(d *schema.ResourceData, meta interface{}) error {
+		start := time.Now()
+		if err := read(d, meta); err != nil {
+			return err
+		}
+		output, ok := d.GetOk("result_output_file")
+		if !ok || output.(string) == "" || !d.Get("include_metadata").(bool) {
+			return nil
+		}
+		return addResultOutputMetadata(output.(string), start)
+	}
+}
+
+// addResultOutputMetadata wraps the file written since `since` with the metadata,
+// a file left from a previous run is not wrapped again.
+func addResultOutputMetadata(filePath string, since time.Time) error {
+	filePath, err := homedir.Expand(filePath)
+	if err != nil {
+		return err
+	}
+	fileInfo, err := os.Stat(filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("stat result output file error,reason %s", err.Error())
+	}
+	if fileInfo.ModTime().Before(since.Truncate(time.Second)) {
+		return nil
+	}
+
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("read result output file error,reason %s", err.Error())
+	}
+	var data interface{} = json.RawMessage(content)
+	if !json.Valid(content) {
+		data = string(content)
+	}
+
+	return writeToFile(filePath, map[string]interface{}{
+		"version":   RESULT_OUTPUT_METADATA_VERSION,
+		"timestamp": time.Now().Format(time.RFC3339),
+		"data":      data,
+	})
+}
+
 // ReadFromFile return file content
 func ReadFromFile(file string) ([]byte, error) {
 	fileName, err := homedir.Expand(file)
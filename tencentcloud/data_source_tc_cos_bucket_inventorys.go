@@ -13,8 +13,6 @@ package tencentcloud
 
 import (
 	"context"
-	"encoding/json"
-	"log"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -27,9 +25,10 @@ func dataSourceTencentCloudCosBucketInventorys() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"bucket": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Bucket.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateCosBucketName,
+				Description:  "Bucket name. Bucket format should be [custom name]-[appid], for example `mycos-1258798060`.",
 			},
 			"inventorys": {
 				Type:        schema.TypeList,
@@ -63,6 +62,11 @@ func dataSourceTencentCloudCosBucketInventorys() *schema.Resource {
 										Computed:    true,
 										Description: "Prefix of the objects to analyze.",
 									},
+									"storage_class": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Storage classes of the objects to analyze, separated by commas.",
+									},
 									"period": {
 										Type:        schema.TypeList,
 										Computed:    true,
@@ -178,76 +182,69 @@ func dataSourceTencentCloudCosBucketInventorysRead(d *schema.ResourceData, meta
 	ctx := context.WithValue(context.TODO(), logIdKey, logId)
 	bucket := d.Get("bucket").(string)
 	inventoryConfigurations := make([]map[string]interface{}, 0)
-	token := ""
 	ids := make([]string, 0)
-	for {
-		result, response, err := meta.(*TencentCloudClient).apiV3Conn.UseTencentCosClient(bucket).Bucket.ListInventoryConfigurations(ctx, token)
-		responseBody, _ := json.Marshal(response.Body)
-		log.Printf("[DEBUG]%s api[ListInventoryConfigurations] success, response body [%s]\n", logId, responseBody)
-		if err != nil {
-			return err
-		}
 
-		for _, item := range result.InventoryConfigurations {
-			itemMap := make(map[string]interface{})
-			itemMap["id"] = item.ID
-			itemMap["is_enabled"] = item.IsEnabled
-			itemMap["included_object_versions"] = item.IncludedObjectVersions
+	cosService := CosService{client: meta.(*TencentCloudClient).apiV3Conn}
+	result, err := cosService.ListBucketInventoryConfigurations(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range result {
+		itemMap := make(map[string]interface{})
+		itemMap["id"] = item.ID
+		itemMap["is_enabled"] = item.IsEnabled
+		itemMap["included_object_versions"] = item.IncludedObjectVersions
 
-			filterMap := make(map[string]interface{})
-			if item.Filter != nil {
-				filterMap["prefix"] = item.Filter.Prefix
-				periodMap := make(map[string]interface{})
-				if item.Filter.Period != nil {
-					if item.Filter.Period.StartTime != 0 {
-						periodMap["start_time"] = strconv.FormatInt(item.Filter.Period.StartTime, 10)
-					}
-					if item.Filter.Period.EndTime != 0 {
-						periodMap["end_time"] = strconv.FormatInt(item.Filter.Period.EndTime, 10)
-					}
-					filterMap["period"] = []interface{}{periodMap}
+		filterMap := make(map[string]interface{})
+		if item.Filter != nil {
+			filterMap["prefix"] = item.Filter.Prefix
+			filterMap["storage_class"] = item.Filter.StorageClass
+			periodMap := make(map[string]interface{})
+			if item.Filter.Period != nil {
+				if item.Filter.Period.StartTime != 0 {
+					periodMap["start_time"] = strconv.FormatInt(item.Filter.Period.StartTime, 10)
 				}
-				itemMap["filter"] = []interface{}{filterMap}
-			}
-			if item.OptionalFields != nil {
-				optionalFieldsMap := make(map[string]interface{})
-				fields := make([]string, 0)
-				if item.OptionalFields.BucketInventoryFields != nil {
-					fields = append(fields, item.OptionalFields.BucketInventoryFields...)
-					optionalFieldsMap["fields"] = fields
+				if item.Filter.Period.EndTime != 0 {
+					periodMap["end_time"] = strconv.FormatInt(item.Filter.Period.EndTime, 10)
 				}
-				itemMap["optional_fields"] = []interface{}{optionalFieldsMap}
+				filterMap["period"] = []interface{}{periodMap}
 			}
-
-			if item.Schedule != nil {
-				scheduleMap := make(map[string]interface{})
-				scheduleMap["frequency"] = item.Schedule.Frequency
-				itemMap["schedule"] = []interface{}{scheduleMap}
+			itemMap["filter"] = []interface{}{filterMap}
+		}
+		if item.OptionalFields != nil {
+			optionalFieldsMap := make(map[string]interface{})
+			fields := make([]string, 0)
+			if item.OptionalFields.BucketInventoryFields != nil {
+				fields = append(fields, item.OptionalFields.BucketInventoryFields...)
+				optionalFieldsMap["fields"] = fields
 			}
+			itemMap["optional_fields"] = []interface{}{optionalFieldsMap}
+		}
 
-			if item.Destination != nil {
-				destinationMap := make(map[string]interface{})
-				destinationMap["bucket"] = item.Destination.Bucket
-				destinationMap["account_id"] = item.Destination.AccountId
-				destinationMap["prefix"] = item.Destination.Prefix
-				destinationMap["format"] = item.Destination.Format
-				if item.Destination.Encryption != nil && item.Destination.Encryption.SSECOS != "" {
-					encryptionMap := make(map[string]interface{})
+		if item.Schedule != nil {
+			scheduleMap := make(map[string]interface{})
+			scheduleMap["frequency"] = item.Schedule.Frequency
+			itemMap["schedule"] = []interface{}{scheduleMap}
+		}
 
-					encryptionMap["sse_cos"] = item.Destination.Encryption.SSECOS
-					destinationMap["encryption"] = []interface{}{encryptionMap}
+		if item.Destination != nil {
+			destinationMap := make(map[string]interface{})
+			destinationMap["bucket"] = item.Destination.Bucket
+			destinationMap["account_id"] = item.Destination.AccountId
+			destinationMap["prefix"] = item.Destination.Prefix
+			destinationMap["format"] = item.Destination.Format
+			if item.Destination.Encryption != nil && item.Destination.Encryption.SSECOS != "" {
+				encryptionMap := make(map[string]interface{})
+
+				encryptionMap["sse_cos"] = item.Destination.Encryption.SSECOS
+				destinationMap["encryption"] = []interface{}{encryptionMap}
 
-				}
-				itemMap["destination"] = []interface{}{destinationMap}
 			}
-			ids = append(ids, item.ID)
-			inventoryConfigurations = append(inventoryConfigurations, itemMap)
-		}
-		if result.NextContinuationToken != "" {
-			token = result.NextContinuationToken
-		} else {
-			break
+			itemMap["destination"] = []interface{}{destinationMap}
 		}
+		ids = append(ids, item.ID)
+		inventoryConfigurations = append(inventoryConfigurations, itemMap)
 	}
 
 	d.SetId(helper.DataResourceIdsHash(ids))
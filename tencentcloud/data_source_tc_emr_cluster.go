@@ -0,0 +1,342 @@
+/*
+Use this data source to query the details of EMR clusters, including the node specs, vpc settings and tags.
+
+A cluster which can not be found results in an empty `clusters` list instead of an error.
+
+Example Usage
+
+```hcl
+data "tencentcloud_emr_cluster" "cluster" {
+  instance_id        = "emr-rnzqrleq"
+  result_output_file = "emr_cluster.json"
+}
+```
+
+Query by display strategy and project
+
+```hcl
+data "tencentcloud_emr_cluster" "clusters" {
+  display_strategy = "clusterList"
+  project_id       = 0
+}
+```
+*/
+package tencentcloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	emr "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/emr/v20190103"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func dataSourceTencentCloudEmrClusterNodeSpecSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Spec of the nodes, empty if the cluster has no such nodes.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"node_size": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Count of the nodes.",
+				},
+				"spec": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Spec of the nodes.",
+				},
+				"instance_type": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Instance type of the nodes.",
+				},
+				"cpu": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "CPU cores of each node.",
+				},
+				"mem_size": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Memory size of each node, in MB.",
+				},
+				"storage_type": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Storage type of each node.",
+				},
+				"disk_type": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Data disk type.",
+				},
+				"disk_size": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Data disk size, in GB.",
+				},
+				"root_size": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "System disk size, in GB.",
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudEmrCluster() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudEmrClusterRead,
+
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Cluster instance ID, e.g. `emr-xxxxxx`. All clusters matching the other filters are queried if it is not set.",
+			},
+			"display_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      DisplayStrategyIsclusterList,
+				ValidateFunc: validateAllowedStringValue(EMR_DISPLAY_STRATEGIES),
+				Description:  "Display strategy(e.g.:clusterList, monitorManage). Default is `clusterList`.",
+			},
+			"project_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     -1,
+				Description: "Fetch the clusters of the project. Default is `-1`, which means all projects.",
+			},
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+			// computed
+			"clusters": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "A list of clusters. Each element contains the following attributes:",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Cluster instance ID.",
+						},
+						"cluster_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the cluster.",
+						},
+						"status": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Status of the cluster, `2` means running.",
+						},
+						"product_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Product ID of the cluster.",
+						},
+						"emr_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "EMR version of the cluster.",
+						},
+						"project_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Project ID of the cluster.",
+						},
+						"zone": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Zone of the cluster.",
+						},
+						"charge_type": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Charge type of the cluster.",
+						},
+						"master_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Master IP of the cluster.",
+						},
+						"add_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Creation time of the cluster.",
+						},
+						"vpc_settings": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "VPC settings of the cluster.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"vpc_id": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "Numeric ID of the VPC, as returned by the EMR API.",
+									},
+									"subnet_id": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "Numeric ID of the subnet, as returned by the EMR API.",
+									},
+									"security_groups": {
+										Type:        schema.TypeList,
+										Computed:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "Security groups of the cluster.",
+									},
+								},
+							},
+						},
+						"master_spec": dataSourceTencentCloudEmrClusterNodeSpecSchema(),
+						"core_spec":   dataSourceTencentCloudEmrClusterNodeSpecSchema(),
+						"task_spec":   dataSourceTencentCloudEmrClusterNodeSpecSchema(),
+						"tags": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "Tags of the cluster.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudEmrClusterRead(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("data_source.tencentcloud_emr_cluster.read")()
+
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+	emrService := EMRService{
+		client: meta.(*TencentCloudClient).apiV3Conn,
+	}
+
+	instanceId := d.Get("instance_id").(string)
+	filters := map[string]interface{}{
+		"display_strategy": d.Get("display_strategy").(string),
+		"project_id":       int64(d.Get("project_id").(int)),
+	}
+
+	var clusters []*emr.ClusterInstancesInfo
+	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		var (
+			result []*emr.ClusterInstancesInfo
+			e      error
+		)
+		if instanceId != "" {
+			result, e = emrService.DescribeInstancesById(ctx, instanceId, filters["display_strategy"].(string))
+		} else {
+			result, e = emrService.DescribeInstances(ctx, filters)
+		}
+		if e != nil {
+			if isExpectError(e, []string{"InternalError.ClusterNotFound"}) {
+				clusters = nil
+				return nil
+			}
+			return retryError(e, InternalError)
+		}
+		clusters = result
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	clusterList := make([]map[string]interface{}, 0, len(clusters))
+	ids := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		if cluster == nil || cluster.ClusterId == nil {
+			continue
+		}
+		clusterList = append(clusterList, flattenEmrClusterInstance(cluster))
+		ids = append(ids, *cluster.ClusterId)
+	}
+
+	d.SetId(helper.DataResourceIdsHash(ids))
+	if err := d.Set("clusters", clusterList); err != nil {
+		log.Printf("[CRITAL]%s provider set EMR cluster list fail, reason:%s\n", logId, err.Error())
+		return err
+	}
+
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if err := writeToFile(output.(string), clusterList); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func flattenEmrClusterInstance(cluster *emr.ClusterInstancesInfo) map[string]interface{} {
+	mapping := map[string]interface{}{
+		"instance_id":  cluster.ClusterId,
+		"cluster_name": cluster.ClusterName,
+		"status":       cluster.Status,
+		"product_id":   cluster.ProductId,
+		"emr_version":  cluster.EmrVersion,
+		"project_id":   cluster.ProjectId,
+		"zone":         cluster.Zone,
+		"charge_type":  cluster.ChargeType,
+		"master_ip":    cluster.MasterIp,
+		"add_time":     cluster.AddTime,
+		"master_spec":  []map[string]interface{}{},
+		"core_spec":    []map[string]interface{}{},
+		"task_spec":    []map[string]interface{}{},
+	}
+
+	vpcSettings := map[string]interface{}{
+		"vpc_id":    cluster.VpcId,
+		"subnet_id": cluster.SubnetId,
+	}
+	if config := cluster.Config; config != nil {
+		vpcSettings["security_groups"] = helper.StringsInterfaces(config.SecurityGroups)
+		mapping["master_spec"] = flattenEmrNodeSpec(config.MasterNodeSize, config.MasterResource)
+		mapping["core_spec"] = flattenEmrNodeSpec(config.CoreNodeSize, config.CoreResource)
+		mapping["task_spec"] = flattenEmrNodeSpec(config.TaskNodeSize, config.TaskResource)
+	}
+	mapping["vpc_settings"] = []map[string]interface{}{vpcSettings}
+
+	tags := make(map[string]interface{}, len(cluster.Tags))
+	for _, tag := range cluster.Tags {
+		if tag == nil || tag.TagKey == nil {
+			continue
+		}
+		tags[*tag.TagKey] = helper.PString(tag.TagValue)
+	}
+	mapping["tags"] = tags
+
+	return mapping
+}
+
+func flattenEmrNodeSpec(size *int64, nodeResource *emr.OutterResource) []map[string]interface{} {
+	if nodeResource == nil {
+		return []map[string]interface{}{}
+	}
+	return []map[string]interface{}{
+		{
+			"node_size":     size,
+			"spec":          nodeResource.Spec,
+			"instance_type": nodeResource.InstanceType,
+			"cpu":           nodeResource.Cpu,
+			"mem_size":      nodeResource.MemSize,
+			"storage_type":  nodeResource.StorageType,
+			"disk_type":     nodeResource.DiskType,
+			"disk_size":     nodeResource.DiskSize,
+			"root_size":     nodeResource.RootSize,
+		},
+	}
+}
@@ -0,0 +1,358 @@
+/*
+Use this data source to query the deployed components and key configuration of an EMR cluster.
+
+Credentials which may be embedded in the metadb connection info are redacted.
+
+Example Usage
+
+```hcl
+data "tencentcloud_emr_cluster_config" "config" {
+  instance_id        = "emr-rnzqrleq"
+  result_output_file = "emr_cluster_config.json"
+}
+```
+*/
+package tencentcloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	emr "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/emr/v20190103"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func dataSourceTencentCloudEmrClusterConfig() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudEmrClusterConfigRead,
+
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Cluster instance ID, e.g. `emr-xxxxxx`.",
+			},
+			"display_strategy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     DisplayStrategyIsclusterList,
+				Description: "Display strategy(e.g.:clusterList, monitorManage). Default is `clusterList`.",
+			},
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+			// computed
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the cluster.",
+			},
+			"product_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Product ID of the cluster.",
+			},
+			"emr_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "EMR version of the cluster.",
+			},
+			"softwares": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Deployed components of the cluster.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the component.",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Version of the component.",
+						},
+					},
+				},
+			},
+			"on_cos": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether COS is used.",
+			},
+			"support_ha": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether HA is supported.",
+			},
+			"security_on": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether security mode is enabled.",
+			},
+			"cbs_encrypt": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Whether CBS encryption is enabled, `1` means enabled.",
+			},
+			"application_role": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Custom application role.",
+			},
+			"security_groups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Security groups of the cluster.",
+			},
+			"meta_db": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Metadb of the cluster, credentials are redacted.",
+			},
+			"hive_meta_db": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hive metadb of the cluster, credentials are redacted.",
+			},
+			"resources": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Node resources of the cluster, one element per node type.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"node_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Node type, `master`, `core`, `task` or `common`.",
+						},
+						"node_size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Count of the nodes.",
+						},
+						"spec": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Spec of the nodes.",
+						},
+						"instance_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Instance type of the nodes.",
+						},
+						"cpu": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "CPU cores of each node.",
+						},
+						"mem_size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Memory size of each node, in MB.",
+						},
+						"disk_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Data disk type.",
+						},
+						"disk_size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Data disk size, in GB.",
+						},
+						"root_size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "System disk size, in GB.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudEmrClusterConfigRead(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("data_source.tencentcloud_emr_cluster_config.read")()
+
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+	emrService := EMRService{
+		client: meta.(*TencentCloudClient).apiV3Conn,
+	}
+
+	instanceId := d.Get("instance_id").(string)
+	displayStrategy := d.Get("display_strategy").(string)
+
+	var clusters []*emr.ClusterInstancesInfo
+	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		result, e := emrService.DescribeInstancesById(ctx, instanceId, displayStrategy)
+		if e != nil {
+			return retryError(e, InternalError)
+		}
+		clusters = result
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(clusters) < 1 {
+		return fmt.Errorf("EMR cluster %s not found", instanceId)
+	}
+
+	cluster := clusters[0]
+	result := map[string]interface{}{
+		"instance_id":  instanceId,
+		"cluster_name": cluster.ClusterName,
+		"product_id":   cluster.ProductId,
+		"emr_version":  cluster.EmrVersion,
+		"meta_db":      redactEmrMetaDb(cluster.MetaDb),
+		"hive_meta_db": redactEmrMetaDb(cluster.HiveMetaDb),
+	}
+
+	softwares := make([]map[string]interface{}, 0)
+	resources := make([]map[string]interface{}, 0)
+	if config := cluster.Config; config != nil {
+		for _, soft := range config.SoftInfo {
+			if soft == nil {
+				continue
+			}
+			softwares = append(softwares, flattenEmrSoftware(*soft))
+		}
+
+		result["on_cos"] = config.OnCos
+		result["support_ha"] = config.SupportHA
+		result["security_on"] = config.SecurityOn
+		result["cbs_encrypt"] = config.CbsEncrypt
+		result["application_role"] = config.ApplicationRole
+		result["security_groups"] = helper.StringsInterfaces(config.SecurityGroups)
+
+		nodeResources := []struct {
+			nodeType string
+			size     *int64
+			resource *emr.OutterResource
+		}{
+			{"master", config.MasterNodeSize, config.MasterResource},
+			{"core", config.CoreNodeSize, config.CoreResource},
+			{"task", config.TaskNodeSize, config.TaskResource},
+			{"common", config.ComNodeSize, config.ComResource},
+		}
+		for _, item := range nodeResources {
+			if item.resource == nil {
+				continue
+			}
+			resources = append(resources, map[string]interface{}{
+				"node_type":     item.nodeType,
+				"node_size":     item.size,
+				"spec":          item.resource.Spec,
+				"instance_type": item.resource.InstanceType,
+				"cpu":           item.resource.Cpu,
+				"mem_size":      item.resource.MemSize,
+				"disk_type":     item.resource.DiskType,
+				"disk_size":     item.resource.DiskSize,
+				"root_size":     item.resource.RootSize,
+			})
+		}
+	}
+	result["softwares"] = softwares
+	result["resources"] = resources
+
+	d.SetId(instanceId)
+	for k, v := range result {
+		if k == "instance_id" {
+			continue
+		}
+		if err := d.Set(k, v); err != nil {
+			log.Printf("[CRITAL]%s provider set %s of EMR cluster config fail, reason:%s\n", logId, k, err.Error())
+			return err
+		}
+	}
+
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if err := writeToFile(output.(string), result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flattenEmrSoftware splits a software like `hdfs-2.8.5` into its name and version.
+func flattenEmrSoftware(soft string) map[string]interface{} {
+	name, version := soft, ""
+	if i := strings.LastIndex(soft, "-"); i > 0 {
+		name, version = soft[:i], soft[i+1:]
+	}
+	return map[string]interface{}{
+		"name":    name,
+		"version": version,
+	}
+}
+
+// redactEmrMetaDb hides the password of a metadb connection info, which may be a jdbc url.
+// Anything which is not a url, such as a Go DSN, is redacted by redactEmrMetaDbUserInfo.
+func redactEmrMetaDb(metaDb *string) string {
+	if metaDb == nil {
+		return ""
+	}
+
+	raw := *metaDb
+	u, err := url.Parse(strings.TrimPrefix(raw, "jdbc:"))
+	if err != nil || u.Scheme == "" || u.Opaque != "" {
+		return redactEmrMetaDbUserInfo(raw)
+	}
+
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), "xxxxx")
+		}
+	}
+	query := u.Query()
+	for k := range query {
+		lower := strings.ToLower(k)
+		if strings.Contains(lower, "pass") || strings.Contains(lower, "secret") {
+			query.Set(k, "xxxxx")
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	if strings.HasPrefix(raw, "jdbc:") {
+		return "jdbc:" + u.String()
+	}
+	return u.String()
+}
+
+var emrMetaDbSecretParamRegexp = regexp.MustCompile(`(?i)([?&;][^=&;]*(?:pass|secret)[^=&;]*=)[^&;]*`)
+
+// redactEmrMetaDbUserInfo masks everything between the first `:` and the last `@` of the user info,
+// e.g. `user:pass@tcp(host:3306)/db`, together with the password parameters.
+func redactEmrMetaDbUserInfo(raw string) string {
+	raw = emrMetaDbSecretParamRegexp.ReplaceAllString(raw, "${1}xxxxx")
+
+	at := strings.LastIndex(raw, "@")
+	if at < 0 {
+		return raw
+	}
+	start := 0
+	if i := strings.Index(raw[:at], "://"); i >= 0 {
+		start = i + len("://")
+	}
+	colon := strings.Index(raw[start:at], ":")
+	if colon < 0 {
+		return raw
+	}
+	colon += start
+	return raw[:colon+1] + "xxxxx" + raw[at:]
+}
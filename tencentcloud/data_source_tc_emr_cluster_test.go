@@ -0,0 +1,86 @@
+package tencentcloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	emr "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/emr/v20190103"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func TestAccDataSourceTencentCloudEMRCluster(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckCommon(t, ACCOUNT_TYPE_COMMON) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEMRClusterDataSource(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTencentCloudDataSourceID("data.tencentcloud_emr_cluster.cluster"),
+					resource.TestCheckResourceAttr("data.tencentcloud_emr_cluster.cluster", "clusters.#", "1"),
+					resource.TestCheckResourceAttr("data.tencentcloud_emr_cluster.cluster", "clusters.0.product_id", "4"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_emr_cluster.cluster", "clusters.0.status"),
+					resource.TestCheckResourceAttr("data.tencentcloud_emr_cluster.cluster", "clusters.0.master_spec.#", "1"),
+					resource.TestCheckResourceAttr("data.tencentcloud_emr_cluster.cluster", "clusters.0.vpc_settings.#", "1"),
+				),
+			},
+			{
+				Config: testAccEMRClusterDataSourceNotFound,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tencentcloud_emr_cluster.not_found", "clusters.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenEmrClusterInstance(t *testing.T) {
+	cluster := &emr.ClusterInstancesInfo{
+		ClusterId: helper.String("emr-xxxxxx"),
+		Status:    helper.IntInt64(2),
+		ProductId: helper.IntInt64(4),
+		VpcId:     helper.IntInt64(100),
+		SubnetId:  helper.IntInt64(200),
+		Config: &emr.EmrProductConfigOutter{
+			MasterNodeSize: helper.IntInt64(1),
+			MasterResource: &emr.OutterResource{Spec: helper.String("CVM.S2"), MemSize: helper.IntInt64(8192)},
+			SecurityGroups: []*string{helper.String("sg-xxxxxx")},
+		},
+		Tags: []*emr.Tag{
+			{TagKey: helper.String("k"), TagValue: helper.String("v")},
+			{TagKey: nil, TagValue: helper.String("ignored")},
+		},
+	}
+
+	mapping := flattenEmrClusterInstance(cluster)
+
+	if masterSpec := mapping["master_spec"].([]map[string]interface{}); len(masterSpec) != 1 || *masterSpec[0]["spec"].(*string) != "CVM.S2" {
+		t.Fatalf("unexpected master_spec: %v", masterSpec)
+	}
+	if coreSpec := mapping["core_spec"].([]map[string]interface{}); len(coreSpec) != 0 {
+		t.Fatalf("core_spec should be empty, got: %v", coreSpec)
+	}
+	vpcSettings := mapping["vpc_settings"].([]map[string]interface{})
+	if len(vpcSettings) != 1 || *vpcSettings[0]["vpc_id"].(*int64) != 100 || len(vpcSettings[0]["security_groups"].([]interface{})) != 1 {
+		t.Fatalf("unexpected vpc_settings: %v", vpcSettings)
+	}
+	tags := mapping["tags"].(map[string]interface{})
+	if len(tags) != 1 || tags["k"] != "v" {
+		t.Fatalf("unexpected tags: %v", tags)
+	}
+}
+
+func testAccEMRClusterDataSource() string {
+	return testEmrBasic + `
+data "tencentcloud_emr_cluster" "cluster" {
+  instance_id = tencentcloud_emr_cluster.emrrrr.instance_id
+}
+`
+}
+
+const testAccEMRClusterDataSourceNotFound = `
+data "tencentcloud_emr_cluster" "not_found" {
+  instance_id = "emr-notfound"
+}
+`
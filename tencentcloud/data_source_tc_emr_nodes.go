@@ -11,6 +11,15 @@ data "tencentcloud_emr_nodes" "my_emr_nodes" {
   instance_id="emr-rnzqrleq"
 }
 ```
+
+Query all the nodes of a cluster, e.g. to build an inventory
+
+```hcl
+data "tencentcloud_emr_nodes" "all_nodes" {
+  instance_id        = "emr-rnzqrleq"
+  result_output_file = "emr_nodes.json"
+}
+```
 */
 package tencentcloud
 
@@ -35,8 +44,9 @@ func dataSourceTencentCloudEmrNodes() *schema.Resource {
 			},
 			"node_flag": {
 				Type:     schema.TypeString,
-				Required: true,
-				Description: `Node ID, the value is:
+				Optional: true,
+				Default:  EMR_NODE_FLAG_ALL,
+				Description: `Node ID, default is all, the value is:
 				- all: Means to get all type nodes, except cdb information.
 				- master: Indicates that the master node information is obtained.
 				- core: Indicates that the core node information is obtained.
@@ -64,8 +74,7 @@ func dataSourceTencentCloudEmrNodes() *schema.Resource {
 			"limit": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Default:     100,
-				Description: "The number returned per page, the default value is 100, and the maximum value is 100.",
+				Description: "The number returned per page, the maximum value is 100. If not set, all the nodes starting from page `offset` are returned.",
 			},
 
 			"result_output_file": {
@@ -407,23 +416,38 @@ func dataSourceTencentCloudEmrNodesRead(d *schema.ResourceData, meta interface{}
 	instanceId := d.Get("instance_id").(string)
 	nodeFlag := d.Get("node_flag").(string)
 	offset := d.Get("offset").(int)
-	limit := d.Get("limit").(int)
 	hardwareResourceType := d.Get("hardware_resource_type").(string)
 
+	// query a single page when limit is set, otherwise query all the pages
+	limit, paged := d.GetOk("limit")
+	pageLimit := EMR_DESCRIBE_CLUSTER_NODE_LIMIT
+	if paged {
+		pageLimit = limit.(int)
+	}
+
 	emrServer := EMRService{
 		client: meta.(*TencentCloudClient).apiV3Conn,
 	}
 	var nodes []*emr.NodeHardwareInfo
-	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
-		var errRet error
-		nodes, errRet = emrServer.DescribeClusterNodes(ctx, instanceId, nodeFlag, hardwareResourceType, offset, limit)
-		if errRet != nil {
-			return retryError(errRet, InternalError)
+	for {
+		var result []*emr.NodeHardwareInfo
+		err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+			var errRet error
+			result, errRet = emrServer.DescribeClusterNodes(ctx, instanceId, nodeFlag, hardwareResourceType, offset, pageLimit)
+			if errRet != nil {
+				return retryError(errRet, InternalError)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		nodes = append(nodes, result...)
+		if paged || len(result) < pageLimit {
+			break
 		}
-		return nil
-	})
-	if err != nil {
-		return err
+		// offset is a page number
+		offset++
 	}
 
 	emrNodes := make([]map[string]interface{}, 0)
@@ -17,6 +17,8 @@ func TestAccDataSourceTencentCloudEMRNodes(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckTencentCloudDataSourceID("data.tencentcloud_emr_nodes.my_emr_nodes"),
 					resource.TestCheckResourceAttr("data.tencentcloud_emr_nodes.my_emr_nodes", "nodes.#", "1"),
+					resource.TestCheckResourceAttr("data.tencentcloud_emr_nodes.all_nodes", "nodes.#", "3"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_emr_nodes.all_nodes", "nodes.0.ip"),
 				),
 			},
 		},
@@ -29,5 +31,9 @@ data "tencentcloud_emr_nodes" "my_emr_nodes" {
   node_flag="master"
   instance_id=tencentcloud_emr_cluster.emrrrr.instance_id
 }
+
+data "tencentcloud_emr_nodes" "all_nodes" {
+  instance_id=tencentcloud_emr_cluster.emrrrr.instance_id
+}
 `
 }
@@ -10,6 +10,26 @@ data "tencentcloud_nat_gateways" "foo" {
   id     = "nat-xfaq1"
 }
 ```
+
+Query NAT gateways by tags
+
+```hcl
+data "tencentcloud_nat_gateways" "by_tags" {
+  vpc_id = "vpc-xfqag"
+  tags = {
+    env = "prod"
+  }
+}
+```
+
+Export all NAT gateways of a VPC, including tags and EIP sets, to a file
+
+```hcl
+data "tencentcloud_nat_gateways" "inventory" {
+  vpc_id             = "vpc-xfqag"
+  result_output_file = "nat_gateways.json"
+}
+```
 */
 package tencentcloud
 
@@ -42,6 +62,11 @@ func dataSourceTencentCloudNatGateways() *schema.Resource {
 				Optional:    true,
 				Description: "ID of the NAT gateway.",
 			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Tags of the NAT gateway, only the NAT gateways with all these tags are returned.",
+			},
 			"result_output_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -96,6 +121,32 @@ func dataSourceTencentCloudNatGateways() *schema.Resource {
 							Computed:    true,
 							Description: "Create time of the NAT gateway.",
 						},
+						"created_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Create time of the NAT gateway, same as `create_time` and the `created_time` of resource `tencentcloud_nat_gateway`.",
+						},
+						"zone": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The availability zone of the NAT gateway.",
+						},
+						"subnet_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the subnet the NAT gateway belongs to.",
+						},
+						"nat_product_version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Product version of the NAT gateway, `1` for the traditional NAT gateway and `2` for the standard NAT gateway.",
+						},
+						"security_group_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "ID list of the security groups bound to the NAT gateway.",
+						},
 						"tags": {
 							Type:        schema.TypeMap,
 							Computed:    true,
@@ -164,16 +215,18 @@ func dataSourceTencentCloudNatGatewaysRead(d *schema.ResourceData, meta interfac
 	}
 	ids := make([]string, 0, len(result))
 	natList := make([]map[string]interface{}, 0, len(result))
+	filterTags := helper.GetTags(d, "tags")
 	for _, nat := range result {
-		mapping := map[string]interface{}{
-			"id":               *nat.NatGatewayId,
-			"vpc_id":           *nat.VpcId,
-			"name":             *nat.NatGatewayName,
-			"max_concurrent":   *nat.MaxConcurrentConnection,
-			"bandwidth":        *nat.InternetMaxBandwidthOut,
-			"state":            *nat.State,
-			"assigned_eip_set": flattenAddressList((*nat).PublicIpAddressSet),
-			"create_time":      *nat.CreatedTime,
+		if !natGatewayHasTags(nat, filterTags) {
+			continue
+		}
+		mapping := flattenNatGateway(nat)
+		mapping["id"] = *nat.NatGatewayId
+		if nat.State != nil {
+			mapping["state"] = *nat.State
+		}
+		if nat.CreatedTime != nil {
+			mapping["create_time"] = *nat.CreatedTime
 		}
 		if nat.TagSet != nil {
 			tags := make(map[string]interface{}, len(nat.TagSet))
@@ -201,3 +254,20 @@ func dataSourceTencentCloudNatGatewaysRead(d *schema.ResourceData, meta interfac
 	return nil
 
 }
+
+// natGatewayHasTags checks whether the NAT gateway carries all the given tags.
+func natGatewayHasTags(nat *vpc.NatGateway, tags map[string]string) bool {
+	for k, v := range tags {
+		matched := false
+		for _, tag := range nat.TagSet {
+			if tag.Key != nil && *tag.Key == k && tag.Value != nil && *tag.Value == v {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
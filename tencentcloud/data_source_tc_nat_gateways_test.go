@@ -4,8 +4,34 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 )
 
+func TestNatGatewayHasTags(t *testing.T) {
+	nat := &vpc.NatGateway{
+		TagSet: []*vpc.Tag{
+			{Key: helper.String("env"), Value: helper.String("prod")},
+			{Key: helper.String("team"), Value: helper.String("network")},
+		},
+	}
+	cases := []struct {
+		tags     map[string]string
+		expected bool
+	}{
+		{nil, true},
+		{map[string]string{"env": "prod"}, true},
+		{map[string]string{"env": "prod", "team": "network"}, true},
+		{map[string]string{"env": "dev"}, false},
+		{map[string]string{"owner": "prod"}, false},
+	}
+	for _, c := range cases {
+		if got := natGatewayHasTags(nat, c.tags); got != c.expected {
+			t.Errorf("tags %v: expected %v, got %v", c.tags, c.expected, got)
+		}
+	}
+}
+
 func TestAccTencentCloudNatGatewaysDataSource(t *testing.T) {
 	t.Parallel()
 	resource.Test(t, resource.TestCase{
@@ -20,6 +46,8 @@ func TestAccTencentCloudNatGatewaysDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.tencentcloud_nat_gateways.multi_nat", "nats.0.name", "terraform_test_nats"),
 					resource.TestCheckResourceAttr("data.tencentcloud_nat_gateways.multi_nat", "nats.1.bandwidth", "500"),
 					//resource.TestCheckResourceAttr("data.tencentcloud_nat_gateways.multi_nat", "nats.0.tags.tf", "test"),
+					resource.TestCheckResourceAttr("data.tencentcloud_nat_gateways.tag_nat", "nats.#", "1"),
+					resource.TestCheckResourceAttrPair("data.tencentcloud_nat_gateways.tag_nat", "nats.0.id", "tencentcloud_nat_gateway.dev_nat", "id"),
 				),
 			},
 		},
@@ -46,6 +74,9 @@ resource "tencentcloud_nat_gateway" "dev_nat" {
   assigned_eip_set = [
     tencentcloud_eip.eip_dev_dnat.public_ip,
   ]
+  tags = {
+    tf = "dev"
+  }
 }
 resource "tencentcloud_nat_gateway" "test_nat" {
   vpc_id           = tencentcloud_vpc.main.id
@@ -61,4 +92,12 @@ data "tencentcloud_nat_gateways" "multi_nat" {
   name           = tencentcloud_nat_gateway.dev_nat.name
   vpc_id         = tencentcloud_vpc.main.id
 }
+
+data "tencentcloud_nat_gateways" "tag_nat" {
+  vpc_id = tencentcloud_vpc.main.id
+  tags = {
+    tf = "dev"
+  }
+  depends_on = [tencentcloud_nat_gateway.dev_nat, tencentcloud_nat_gateway.test_nat]
+}
 `
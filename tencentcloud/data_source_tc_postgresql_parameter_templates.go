@@ -22,6 +22,8 @@ package tencentcloud
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -130,7 +132,11 @@ func dataSourceTencentCloudPostgresqlParameterTemplatesRead(d *schema.ResourceDa
 			filterMap := item.(map[string]interface{})
 
 			if v, ok := filterMap["name"]; ok {
-				filter.Name = helper.String(v.(string))
+				name := v.(string)
+				if !IsContains(POSTGRESQL_PARAMETER_TEMPLATE_FILTER_NAMES, name) {
+					return fmt.Errorf("unsupported filter name `%s`, valid values: %s", name, strings.Join(POSTGRESQL_PARAMETER_TEMPLATE_FILTER_NAMES, ", "))
+				}
+				filter.Name = helper.String(name)
 			}
 			if v, ok := filterMap["values"]; ok {
 				valuesSet := v.(*schema.Set).List()
@@ -142,11 +148,11 @@ func dataSourceTencentCloudPostgresqlParameterTemplatesRead(d *schema.ResourceDa
 	}
 
 	if v, ok := d.GetOk("order_by"); ok {
-		paramMap["OrderBy"] = helper.String(v.(string))
+		paramMap["order_by"] = helper.String(v.(string))
 	}
 
 	if v, ok := d.GetOk("order_by_type"); ok {
-		paramMap["OrderByType"] = helper.String(v.(string))
+		paramMap["order_by_type"] = helper.String(v.(string))
 	}
 
 	service := PostgresqlService{client: meta.(*TencentCloudClient).apiV3Conn}
@@ -1,6 +1,7 @@
 package tencentcloud
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -27,8 +28,14 @@ func TestAccTencentCloudPostgresqlParameterTemplatesDataSource_basic(t *testing.
 					resource.TestCheckResourceAttrSet("data.tencentcloud_postgresql_parameter_templates.parameter_templates", "list.#"),
 					resource.TestCheckResourceAttr("data.tencentcloud_postgresql_parameter_templates.parameter_templates", "list.#", "1"),
 					resource.TestCheckResourceAttr("data.tencentcloud_postgresql_parameter_templates.parameter_templates", "list.0.template_name", "tf_test_pg_temp_ds"),
+					resource.TestCheckResourceAttr("data.tencentcloud_postgresql_parameter_templates.major_version", "list.#", "1"),
+					resource.TestCheckResourceAttr("data.tencentcloud_postgresql_parameter_templates.major_version", "list.0.db_major_version", "13"),
 				),
 			},
+			{
+				Config:      testAccPostgresqlParameterTemplatesDataSourceUnknownFilter,
+				ExpectError: regexp.MustCompile("unsupported filter name `Unknown`"),
+			},
 		},
 	})
 }
@@ -64,4 +71,26 @@ data "tencentcloud_postgresql_parameter_templates" "parameter_templates" {
   order_by_type = "desc"
 }
 
+data "tencentcloud_postgresql_parameter_templates" "major_version" {
+  filters {
+	name = "TemplateName"
+	values = [tencentcloud_postgresql_parameter_template.temp1.template_name]
+  }
+  filters {
+	name = "DBMajorVersion"
+	values = [tencentcloud_postgresql_parameter_template.temp1.db_major_version]
+  }
+}
+
+`
+
+const testAccPostgresqlParameterTemplatesDataSourceUnknownFilter = `
+
+data "tencentcloud_postgresql_parameter_templates" "parameter_templates" {
+  filters {
+	name = "Unknown"
+	values = ["unknown"]
+  }
+}
+
 `
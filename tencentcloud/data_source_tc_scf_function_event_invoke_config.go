@@ -0,0 +1,128 @@
+/*
+Use this data source to query the async invoke config of a scf function, in the same shape as resource `tencentcloud_scf_function_event_invoke_config`
+
+Example Usage
+
+```hcl
+data "tencentcloud_scf_function_event_invoke_config" "function_event_invoke_config" {
+  function_name = "keep-1676351130"
+  namespace     = "default"
+  qualifier     = "$LATEST"
+}
+```
+*/
+package tencentcloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	scf "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/scf/v20180416"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func dataSourceTencentCloudScfFunctionEventInvokeConfig() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudScfFunctionEventInvokeConfigRead,
+		Schema: map[string]*schema.Schema{
+			"function_name": {
+				Required:    true,
+				Type:        schema.TypeString,
+				Description: "Function name.",
+			},
+
+			"namespace": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Default:     "default",
+				Description: "Function namespace. Default value: default.",
+			},
+
+			"qualifier": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Default:     SCF_FUNCTION_QUALIFIER_LATEST,
+				Description: "Function version or alias. Default value: $LATEST.",
+			},
+
+			"async_trigger_config": {
+				Computed:    true,
+				Type:        schema.TypeList,
+				Description: "Async retry configuration information.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"retry_config": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Async retry configuration of function upon user error.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"retry_num": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "Number of retry attempts.",
+									},
+								},
+							},
+						},
+						"msg_ttl": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Message retention period, in seconds.",
+						},
+					},
+				},
+			},
+
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudScfFunctionEventInvokeConfigRead(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("data_source.tencentcloud_scf_function_event_invoke_config.read")()
+	defer inconsistentCheck(d, meta)()
+
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+	service := ScfService{client: meta.(*TencentCloudClient).apiV3Conn}
+
+	functionName := d.Get("function_name").(string)
+	namespace := d.Get("namespace").(string)
+	qualifier := d.Get("qualifier").(string)
+
+	var asyncTriggerConfig *scf.AsyncTriggerConfig
+	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		result, e := service.DescribeScfFunctionEventInvokeConfigByQualifier(ctx, namespace, functionName, qualifier)
+		if e != nil {
+			return retryError(e)
+		}
+		asyncTriggerConfig = result
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if asyncTriggerConfig == nil {
+		return fmt.Errorf("scf function %s of namespace %s not found", functionName, namespace)
+	}
+
+	asyncTriggerConfigList := []interface{}{flattenScfAsyncTriggerConfig(asyncTriggerConfig)}
+	_ = d.Set("async_trigger_config", asyncTriggerConfigList)
+
+	d.SetId(helper.IdFormat(functionName, namespace, qualifier))
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if e := writeToFile(output.(string), asyncTriggerConfigList); e != nil {
+			return e
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package tencentcloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTencentCloudScfFunctionEventInvokeConfigDataSource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccScfFunctionEventInvokeConfigDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTencentCloudDataSourceID("data.tencentcloud_scf_function_event_invoke_config.function_event_invoke_config"),
+					resource.TestCheckResourceAttr("data.tencentcloud_scf_function_event_invoke_config.function_event_invoke_config", "async_trigger_config.#", "1"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_scf_function_event_invoke_config.function_event_invoke_config", "async_trigger_config.0.msg_ttl"),
+				),
+			},
+		},
+	})
+}
+
+const testAccScfFunctionEventInvokeConfigDataSource = `
+
+data "tencentcloud_scf_function_event_invoke_config" "function_event_invoke_config" {
+  function_name = "keep-1676351130"
+  namespace     = "default"
+}
+
+`
@@ -0,0 +1,158 @@
+/*
+Use this data source to query the async invoke configs of a scf function, one per qualifier
+
+Example Usage
+
+```hcl
+data "tencentcloud_scf_function_event_invoke_configs" "function_event_invoke_configs" {
+  function_name = "keep-1676351130"
+  namespace     = "default"
+  qualifiers    = ["$LATEST", "1"]
+}
+```
+*/
+package tencentcloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	scf "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/scf/v20180416"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func dataSourceTencentCloudScfFunctionEventInvokeConfigs() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudScfFunctionEventInvokeConfigsRead,
+		Schema: map[string]*schema.Schema{
+			"function_name": {
+				Required:    true,
+				Type:        schema.TypeString,
+				Description: "Function name.",
+			},
+
+			"namespace": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Default:     "default",
+				Description: "Function namespace. Default value: default.",
+			},
+
+			"qualifiers": {
+				Optional:    true,
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Function versions or aliases to query. Default value: [\"$LATEST\"].",
+			},
+
+			"configs": {
+				Computed:    true,
+				Type:        schema.TypeList,
+				Description: "Async invoke configs, in the order of `qualifiers`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"qualifier": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Function version or alias the config applies to.",
+						},
+						"retry_config": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Async retry configuration of function upon user error.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"retry_num": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "Number of retry attempts.",
+									},
+								},
+							},
+						},
+						"msg_ttl": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Message retention period.",
+						},
+					},
+				},
+			},
+
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudScfFunctionEventInvokeConfigsRead(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("data_source.tencentcloud_scf_function_event_invoke_configs.read")()
+	defer inconsistentCheck(d, meta)()
+
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+	service := ScfService{client: meta.(*TencentCloudClient).apiV3Conn}
+
+	functionName := d.Get("function_name").(string)
+	namespace := d.Get("namespace").(string)
+	qualifiers := []string{SCF_FUNCTION_QUALIFIER_LATEST}
+	if v, ok := d.GetOk("qualifiers"); ok && len(v.([]interface{})) > 0 {
+		qualifiers = helper.InterfacesStrings(v.([]interface{}))
+	}
+
+	configs := make([]map[string]interface{}, 0, len(qualifiers))
+	for _, qualifier := range qualifiers {
+		var asyncTriggerConfig *scf.AsyncTriggerConfig
+		err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+			result, e := service.DescribeScfFunctionEventInvokeConfigByQualifier(ctx, namespace, functionName, qualifier)
+			if e != nil {
+				return retryError(e)
+			}
+			asyncTriggerConfig = result
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if asyncTriggerConfig == nil {
+			return fmt.Errorf("scf function %s of namespace %s not found", functionName, namespace)
+		}
+
+		configMap := map[string]interface{}{
+			"qualifier": qualifier,
+		}
+		if asyncTriggerConfig.RetryConfig != nil {
+			retryConfigList := []interface{}{}
+			for _, retryConfig := range asyncTriggerConfig.RetryConfig {
+				retryConfigMap := map[string]interface{}{}
+				if retryConfig.RetryNum != nil {
+					retryConfigMap["retry_num"] = retryConfig.RetryNum
+				}
+				retryConfigList = append(retryConfigList, retryConfigMap)
+			}
+			configMap["retry_config"] = retryConfigList
+		}
+		if asyncTriggerConfig.MsgTTL != nil {
+			configMap["msg_ttl"] = asyncTriggerConfig.MsgTTL
+		}
+		configs = append(configs, configMap)
+	}
+
+	_ = d.Set("configs", configs)
+
+	d.SetId(strings.Join([]string{functionName, namespace}, FILED_SP))
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if e := writeToFile(output.(string), configs); e != nil {
+			return e
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package tencentcloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTencentCloudScfFunctionEventInvokeConfigsDataSource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccScfFunctionEventInvokeConfigsDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTencentCloudDataSourceID("data.tencentcloud_scf_function_event_invoke_configs.function_event_invoke_configs"),
+					resource.TestCheckResourceAttr("data.tencentcloud_scf_function_event_invoke_configs.function_event_invoke_configs", "configs.#", "1"),
+					resource.TestCheckResourceAttr("data.tencentcloud_scf_function_event_invoke_configs.function_event_invoke_configs", "configs.0.qualifier", "$LATEST"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_scf_function_event_invoke_configs.function_event_invoke_configs", "configs.0.msg_ttl"),
+				),
+			},
+		},
+	})
+}
+
+const testAccScfFunctionEventInvokeConfigsDataSource = `
+
+data "tencentcloud_scf_function_event_invoke_configs" "function_event_invoke_configs" {
+  function_name = "keep-1676351130"
+  namespace     = "default"
+}
+
+`
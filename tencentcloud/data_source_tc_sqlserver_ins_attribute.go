@@ -88,6 +88,31 @@ func dataSourceTencentCloudSqlserverInsAttribute() *schema.Resource {
 					},
 				},
 			},
+			"instance_status": {
+				Computed:    true,
+				Type:        schema.TypeInt,
+				Description: "Status of the instance. 1 for applying, 2 for running, 3 for running with limit, 4 for isolated, 5 for recycling, 6 for recycled, 7 for running with task, 8 for off-line, 9 for expanding, 10 for migrating, 11 for readonly, 12 for rebooting.",
+			},
+			"region": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "Region of the instance, such as `ap-guangzhou`.",
+			},
+			"engine_version": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "Engine version of the instance, such as `2008R2`.",
+			},
+			"vip": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "Private IP of the instance.",
+			},
+			"vport": {
+				Computed:    true,
+				Type:        schema.TypeInt,
+				Description: "Private port of the instance.",
+			},
 			"result_output_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -106,6 +131,7 @@ func dataSourceTencentCloudSqlserverInsAttributeRead(d *schema.ResourceData, met
 		ctx          = context.WithValue(context.TODO(), logIdKey, logId)
 		service      = SqlserverService{client: meta.(*TencentCloudClient).apiV3Conn}
 		insAttribute *sqlserver.DescribeDBInstancesAttributeResponseParams
+		instance     *sqlserver.DBInstance
 		instanceId   string
 	)
 
@@ -129,6 +155,20 @@ func dataSourceTencentCloudSqlserverInsAttributeRead(d *schema.ResourceData, met
 		return err
 	}
 
+	err = resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		result, _, e := service.DescribeSqlserverInstanceById(ctx, instanceId)
+		if e != nil {
+			return retryError(e)
+		}
+
+		instance = result
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
 	if insAttribute.InstanceId != nil {
 		_ = d.Set("instance_id", instanceId)
 	}
@@ -181,6 +221,28 @@ func dataSourceTencentCloudSqlserverInsAttributeRead(d *schema.ResourceData, met
 		_ = d.Set("tde_config", tmpList)
 	}
 
+	if instance != nil {
+		if instance.Status != nil {
+			_ = d.Set("instance_status", instance.Status)
+		}
+
+		if instance.Region != nil {
+			_ = d.Set("region", instance.Region)
+		}
+
+		if instance.Version != nil {
+			_ = d.Set("engine_version", instance.Version)
+		}
+
+		if instance.Vip != nil {
+			_ = d.Set("vip", instance.Vip)
+		}
+
+		if instance.Vport != nil {
+			_ = d.Set("vport", instance.Vport)
+		}
+	}
+
 	d.SetId(instanceId)
 	output, ok := d.GetOk("result_output_file")
 	if ok && output.(string) != "" {
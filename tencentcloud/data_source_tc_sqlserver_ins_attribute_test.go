@@ -20,6 +20,10 @@ func TestAccTencentCloudSqlserverInsAttributeDataSource_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckTencentCloudDataSourceID("data.tencentcloud_sqlserver_ins_attribute.example"),
 					resource.TestCheckResourceAttrSet("data.tencentcloud_sqlserver_ins_attribute.example", "instance_id"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_sqlserver_ins_attribute.example", "instance_status"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_sqlserver_ins_attribute.example", "region"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_sqlserver_ins_attribute.example", "vip"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_sqlserver_ins_attribute.example", "vport"),
 				),
 			},
 		},
@@ -0,0 +1,242 @@
+/*
+Use this data source to query detailed attributes of multiple sqlserver instances
+
+Example Usage
+
+```hcl
+data "tencentcloud_sqlserver_ins_attributes" "example" {
+  instance_ids = ["mssql-gyg9xycl", "mssql-qelbzgwf"]
+}
+```
+*/
+package tencentcloud
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sqlserver "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/sqlserver/v20180328"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func dataSourceTencentCloudSqlserverInsAttributes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudSqlserverInsAttributesRead,
+		Schema: map[string]*schema.Schema{
+			"instance_ids": {
+				Required:    true,
+				Type:        schema.TypeList,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Instance ID list.",
+			},
+			"instance_attributes": {
+				Computed:    true,
+				Type:        schema.TypeList,
+				Description: "Attributes of the instances, in the order of `instance_ids`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Instance ID.",
+						},
+						"error": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Error message when the attributes of this instance failed to be queried, other attributes are empty then.",
+						},
+						"regular_backup_enable": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Archive backup status. Valid values: enable (enabled), disable (disabled).",
+						},
+						"regular_backup_save_days": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Archive backup retention period: [90-3650] days.",
+						},
+						"regular_backup_strategy": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Archive backup policy. Valid values: years (yearly); quarters (quarterly);months` (monthly).",
+						},
+						"regular_backup_counts": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of retained archive backups.",
+						},
+						"regular_backup_start_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Archive backup start date in YYYY-MM-DD format, which is the current time by default.",
+						},
+						"blocked_threshold": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Block process threshold in milliseconds.",
+						},
+						"event_save_days": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Retention period for the files of slow SQL, blocking, deadlock, and extended events.",
+						},
+						"tde_config": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "TDE Transparent Data Encryption Configuration.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"certificate_attribution": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Certificate ownership. Self - indicates using the account's own certificate, others - indicates referencing certificates from other accounts, and none - indicates no certificate.",
+									},
+									"encryption": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "TDE encryption, 'enable' - enabled, 'disable' - not enabled.",
+									},
+									"quote_uin": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Other primary account IDs referenced when activating TDE encryption\nNote: This field may return null, indicating that a valid value cannot be obtained.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudSqlserverInsAttributesRead(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("data_source.tencentcloud_sqlserver_ins_attributes.read")()
+	defer inconsistentCheck(d, meta)()
+
+	var (
+		logId   = getLogId(contextNil)
+		ctx     = context.WithValue(context.TODO(), logIdKey, logId)
+		service = SqlserverService{client: meta.(*TencentCloudClient).apiV3Conn}
+	)
+
+	instanceIds := helper.InterfacesStrings(d.Get("instance_ids").([]interface{}))
+	attributes := make([]map[string]interface{}, len(instanceIds))
+
+	g := NewGoRoutine(SQLSERVER_INS_ATTRIBUTE_CONCURRENCY)
+	wg := sync.WaitGroup{}
+	for i, instanceId := range instanceIds {
+		wg.Add(1)
+		index, id := i, instanceId
+		goFunc := func() {
+			defer wg.Done()
+
+			var insAttribute *sqlserver.DescribeDBInstancesAttributeResponseParams
+			paramMap := map[string]interface{}{"InstanceId": helper.String(id)}
+			err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+				result, e := service.DescribeSqlserverInsAttributeByFilter(ctx, paramMap)
+				if e != nil {
+					return retryError(e)
+				}
+
+				insAttribute = result
+				return nil
+			})
+
+			// a failed instance is reported in its own element, the others are still returned
+			if err != nil {
+				log.Printf("[WARN]%s read attribute of sqlserver instance [%s] failed, reason:%+v", logId, id, err)
+				attributes[index] = map[string]interface{}{
+					"instance_id": id,
+					"error":       err.Error(),
+				}
+				return
+			}
+
+			attributes[index] = flattenSqlserverInsAttribute(id, insAttribute)
+		}
+		g.Run(goFunc)
+	}
+	wg.Wait()
+
+	d.SetId(helper.DataResourceIdsHash(instanceIds))
+	if e := d.Set("instance_attributes", attributes); e != nil {
+		log.Printf("[CRITAL]%s provider set sqlserver instance attributes fail, reason:%s\n", logId, e.Error())
+		return e
+	}
+
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if e := writeToFile(output.(string), attributes); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+func flattenSqlserverInsAttribute(instanceId string, insAttribute *sqlserver.DescribeDBInstancesAttributeResponseParams) map[string]interface{} {
+	attributeMap := map[string]interface{}{
+		"instance_id": instanceId,
+	}
+	if insAttribute == nil {
+		return attributeMap
+	}
+
+	if insAttribute.RegularBackupEnable != nil {
+		attributeMap["regular_backup_enable"] = insAttribute.RegularBackupEnable
+	}
+
+	if insAttribute.RegularBackupSaveDays != nil {
+		attributeMap["regular_backup_save_days"] = insAttribute.RegularBackupSaveDays
+	}
+
+	if insAttribute.RegularBackupStrategy != nil {
+		attributeMap["regular_backup_strategy"] = insAttribute.RegularBackupStrategy
+	}
+
+	if insAttribute.RegularBackupCounts != nil {
+		attributeMap["regular_backup_counts"] = insAttribute.RegularBackupCounts
+	}
+
+	if insAttribute.RegularBackupStartTime != nil {
+		attributeMap["regular_backup_start_time"] = insAttribute.RegularBackupStartTime
+	}
+
+	if insAttribute.BlockedThreshold != nil {
+		attributeMap["blocked_threshold"] = insAttribute.BlockedThreshold
+	}
+
+	if insAttribute.EventSaveDays != nil {
+		attributeMap["event_save_days"] = insAttribute.EventSaveDays
+	}
+
+	if insAttribute.TDEConfig != nil {
+		configMap := map[string]interface{}{}
+		if insAttribute.TDEConfig.CertificateAttribution != nil {
+			configMap["certificate_attribution"] = insAttribute.TDEConfig.CertificateAttribution
+		}
+
+		if insAttribute.TDEConfig.Encryption != nil {
+			configMap["encryption"] = insAttribute.TDEConfig.Encryption
+		}
+
+		if insAttribute.TDEConfig.QuoteUin != nil {
+			configMap["quote_uin"] = insAttribute.TDEConfig.QuoteUin
+		}
+
+		attributeMap["tde_config"] = []map[string]interface{}{configMap}
+	}
+
+	return attributeMap
+}
@@ -0,0 +1,34 @@
+package tencentcloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTencentCloudSqlserverInsAttributesDataSource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSqlserverInsAttributesDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTencentCloudDataSourceID("data.tencentcloud_sqlserver_ins_attributes.example"),
+					resource.TestCheckResourceAttr("data.tencentcloud_sqlserver_ins_attributes.example", "instance_attributes.#", "1"),
+					resource.TestCheckResourceAttr("data.tencentcloud_sqlserver_ins_attributes.example", "instance_attributes.0.error", ""),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_sqlserver_ins_attributes.example", "instance_attributes.0.regular_backup_enable"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSqlserverInsAttributesDataSource = `
+data "tencentcloud_sqlserver_ins_attributes" "example" {
+  instance_ids = ["mssql-gyg9xycl"]
+}
+`
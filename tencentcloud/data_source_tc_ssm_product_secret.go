@@ -0,0 +1,173 @@
+/*
+Use this data source to query detailed information of a SSM product secret
+
+~> **NOTE:** The SSM API does not return `user_name_prefix`, `domains` and `privileges_list` of a product secret, so they are not exported.
+
+Example Usage
+
+```hcl
+data "tencentcloud_ssm_product_secret" "product_secret" {
+  secret_name = "tf-product-ssm-test"
+}
+```
+*/
+package tencentcloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	ssm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/ssm/v20190923"
+)
+
+func dataSourceTencentCloudSsmProductSecret() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudSsmProductSecretRead,
+		Schema: map[string]*schema.Schema{
+			"secret_name": {
+				Required:    true,
+				Type:        schema.TypeString,
+				Description: "Name of the product secret.",
+			},
+
+			"product_name": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "Name of the Tencent Cloud service bound to the secret, such as `Mysql`.",
+			},
+
+			"instance_id": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "Tencent Cloud service instance ID.",
+			},
+
+			"status": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "Status of the secret, such as `Enabled`, `Disabled`, `PendingDelete`.",
+			},
+
+			"kms_key_id": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "KMS CMK that encrypts the secret.",
+			},
+
+			"description": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "Description of the secret.",
+			},
+
+			"enable_rotation": {
+				Computed:    true,
+				Type:        schema.TypeBool,
+				Description: "Whether rotation is enabled.",
+			},
+
+			"rotation_frequency": {
+				Computed:    true,
+				Type:        schema.TypeInt,
+				Description: "Rotation frequency in days.",
+			},
+
+			"create_time": {
+				Computed:    true,
+				Type:        schema.TypeInt,
+				Description: "Credential creation time in UNIX timestamp format.",
+			},
+
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudSsmProductSecretRead(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("data_source.tencentcloud_ssm_product_secret.read")()
+	defer inconsistentCheck(d, meta)()
+
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+	service := SsmService{client: meta.(*TencentCloudClient).apiV3Conn}
+
+	secretName := d.Get("secret_name").(string)
+
+	var productSecret *ssm.SecretMetadata
+	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		result, e := service.DescribeSecretById(ctx, secretName, 1)
+		if e != nil {
+			return retryError(e)
+		}
+		productSecret = result
+		return nil
+	})
+	if err != nil {
+		log.Printf("[CRITAL]%s read SSM product secret failed, reason:%+v", logId, err)
+		return err
+	}
+
+	result := map[string]interface{}{
+		"secret_name": secretName,
+	}
+	if productSecret == nil {
+		log.Printf("[WARN]%s SSM product secret [%s] not found\n", logId, secretName)
+	} else {
+		if productSecret.ProductName != nil {
+			_ = d.Set("product_name", productSecret.ProductName)
+			result["product_name"] = productSecret.ProductName
+		}
+
+		if productSecret.ResourceID != nil {
+			_ = d.Set("instance_id", productSecret.ResourceID)
+			result["instance_id"] = productSecret.ResourceID
+		}
+
+		if productSecret.Status != nil {
+			_ = d.Set("status", productSecret.Status)
+			result["status"] = productSecret.Status
+		}
+
+		if productSecret.KmsKeyId != nil {
+			_ = d.Set("kms_key_id", productSecret.KmsKeyId)
+			result["kms_key_id"] = productSecret.KmsKeyId
+		}
+
+		if productSecret.Description != nil {
+			_ = d.Set("description", productSecret.Description)
+			result["description"] = productSecret.Description
+		}
+
+		if productSecret.RotationStatus != nil {
+			enableRotation := *productSecret.RotationStatus != 0
+			_ = d.Set("enable_rotation", enableRotation)
+			result["enable_rotation"] = enableRotation
+		}
+
+		if productSecret.RotationFrequency != nil {
+			_ = d.Set("rotation_frequency", productSecret.RotationFrequency)
+			result["rotation_frequency"] = productSecret.RotationFrequency
+		}
+
+		if productSecret.CreateTime != nil {
+			_ = d.Set("create_time", productSecret.CreateTime)
+			result["create_time"] = productSecret.CreateTime
+		}
+	}
+
+	d.SetId(secretName)
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if e := writeToFile(output.(string), result); e != nil {
+			return e
+		}
+	}
+	return nil
+}
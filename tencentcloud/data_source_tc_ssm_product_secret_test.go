@@ -0,0 +1,69 @@
+package tencentcloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTencentCloudSsmProductSecretDataSource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSsmProductSecretDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTencentCloudDataSourceID("data.tencentcloud_ssm_product_secret.product_secret"),
+					resource.TestCheckResourceAttr("data.tencentcloud_ssm_product_secret.product_secret", "product_name", "Mysql"),
+					resource.TestCheckResourceAttr("data.tencentcloud_ssm_product_secret.product_secret", "description", "for ssm product data source test"),
+					resource.TestCheckResourceAttrPair("data.tencentcloud_ssm_product_secret.product_secret", "instance_id", "tencentcloud_ssm_product_secret.product_secret", "instance_id"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_ssm_product_secret.product_secret", "status"),
+				),
+			},
+			{
+				Config: testAccSsmProductSecretDataSourceNotFound,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTencentCloudDataSourceID("data.tencentcloud_ssm_product_secret.not_found"),
+					resource.TestCheckResourceAttr("data.tencentcloud_ssm_product_secret.not_found", "product_name", ""),
+				),
+			},
+		},
+	})
+}
+
+const testAccSsmProductSecretDataSource = `
+
+data "tencentcloud_mysql_instance" "mysql" {
+  mysql_id = "cdb-fitq5t9h"
+}
+
+resource "tencentcloud_ssm_product_secret" "product_secret" {
+  secret_name      = "tf-product-ssm-data-test"
+  user_name_prefix = "test"
+  product_name     = "Mysql"
+  instance_id      = data.tencentcloud_mysql_instance.mysql.instance_list.0.mysql_id
+  domains          = ["10.0.0.0"]
+  privileges_list {
+    privilege_name = "GlobalPrivileges"
+    privileges     = ["ALTER ROUTINE"]
+  }
+  description = "for ssm product data source test"
+}
+
+data "tencentcloud_ssm_product_secret" "product_secret" {
+  secret_name = tencentcloud_ssm_product_secret.product_secret.secret_name
+}
+
+`
+
+const testAccSsmProductSecretDataSourceNotFound = `
+
+data "tencentcloud_ssm_product_secret" "not_found" {
+  secret_name = "tf-product-ssm-not-exist"
+}
+
+`
@@ -0,0 +1,128 @@
+/*
+Use this data source to query the plugins attached to a tse gateway route
+
+~> **NOTE:** Only the rate limit plugin of a route can be queried by the API currently.
+
+Example Usage
+
+```hcl
+data "tencentcloud_tse_gateway_route_plugins" "gateway_route_plugins" {
+  gateway_id = "gateway-xxxxxx"
+  route_id   = "7a6ca0b0-1cc6-4d2b-b6f9-1e6c6b9d3cb5"
+}
+```
+*/
+package tencentcloud
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tse "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/tse/v20201207"
+)
+
+func dataSourceTencentCloudTseGatewayRoutePlugins() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudTseGatewayRoutePluginsRead,
+		Schema: map[string]*schema.Schema{
+			"gateway_id": {
+				Required:    true,
+				Type:        schema.TypeString,
+				Description: "gateway ID.",
+			},
+
+			"route_id": {
+				Required:    true,
+				Type:        schema.TypeString,
+				Description: "route ID or route name.",
+			},
+
+			"plugins": {
+				Computed:    true,
+				Type:        schema.TypeList,
+				Description: "plugins attached to the route.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "plugin name, such as `rate-limiting`.",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "whether the plugin is enabled.",
+						},
+						"config": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "plugin configuration in JSON format.",
+						},
+					},
+				},
+			},
+
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudTseGatewayRoutePluginsRead(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("data_source.tencentcloud_tse_gateway_route_plugins.read")()
+	defer inconsistentCheck(d, meta)()
+
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+	gatewayId := d.Get("gateway_id").(string)
+	routeId := d.Get("route_id").(string)
+
+	service := TseService{client: meta.(*TencentCloudClient).apiV3Conn}
+
+	var rateLimit *tse.CloudNativeAPIGatewayRateLimitDetail
+	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		response, e := service.DescribeTseCngwRouteRateLimitById(ctx, gatewayId, routeId)
+		if e != nil {
+			return retryError(e)
+		}
+		rateLimit = response
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	plugins := make([]map[string]interface{}, 0)
+	if rateLimit != nil {
+		config, e := json.Marshal(rateLimit)
+		if e != nil {
+			return e
+		}
+
+		pluginMap := map[string]interface{}{
+			"name":   TSE_PLUGIN_NAME_RATE_LIMITING,
+			"config": string(config),
+		}
+		if rateLimit.Enabled != nil {
+			pluginMap["enabled"] = rateLimit.Enabled
+		}
+		plugins = append(plugins, pluginMap)
+	}
+	_ = d.Set("plugins", plugins)
+
+	d.SetId(strings.Join([]string{gatewayId, routeId}, FILED_SP))
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if e := writeToFile(output.(string), plugins); e != nil {
+			return e
+		}
+	}
+	return nil
+}
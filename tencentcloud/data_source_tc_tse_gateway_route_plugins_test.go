@@ -0,0 +1,39 @@
+package tencentcloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// go test -i; go test -test.run TestAccTencentCloudTseGatewayRoutePluginsDataSource_basic -v
+func TestAccTencentCloudTseGatewayRoutePluginsDataSource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTseGatewayRoutePluginsDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTencentCloudDataSourceID("data.tencentcloud_tse_gateway_route_plugins.gateway_route_plugins"),
+					resource.TestCheckResourceAttr("data.tencentcloud_tse_gateway_route_plugins.gateway_route_plugins", "plugins.#", "1"),
+					resource.TestCheckResourceAttr("data.tencentcloud_tse_gateway_route_plugins.gateway_route_plugins", "plugins.0.name", "rate-limiting"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_tse_gateway_route_plugins.gateway_route_plugins", "plugins.0.enabled"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_tse_gateway_route_plugins.gateway_route_plugins", "plugins.0.config"),
+				),
+			},
+		},
+	})
+}
+
+const testAccTseGatewayRoutePluginsDataSource = `
+
+data "tencentcloud_tse_gateway_route_plugins" "gateway_route_plugins" {
+	gateway_id = "gateway-ddbb709b"
+	route_id   = "7a6ca0b0-1cc6-4d2b-b6f9-1e6c6b9d3cb5"
+}
+
+`
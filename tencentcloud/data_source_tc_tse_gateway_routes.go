@@ -208,7 +208,8 @@ func dataSourceTencentCloudTseGatewayRoutesRead(d *schema.ResourceData, meta int
 	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
 		response, e := service.DescribeTseGatewayRoutesByFilter(ctx, paramMap)
 		if e != nil {
-			return retryError(e)
+			// the gateway may be still provisioning when it is created in the same apply
+			return retryError(e, TSE_GATEWAY_NOT_READY_ERROR_CODES...)
 		}
 		result = response
 		return nil
@@ -1,9 +1,11 @@
 package tencentcloud
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccTencentCloudNeedFixTseGatewayRoutesDataSource_basic(t *testing.T) {
@@ -43,6 +45,41 @@ func TestAccTencentCloudNeedFixTseGatewayRoutesDataSource_basic(t *testing.T) {
 	})
 }
 
+func TestAccTencentCloudNeedFixTseGatewayRoutesDataSource_pagination(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTseGatewayRoutesDataSourcePagination,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTencentCloudDataSourceID("data.tencentcloud_tse_gateway_routes.gateway_routes"),
+					resource.TestCheckResourceAttr("data.tencentcloud_tse_gateway_routes.gateway_routes", "result.0.route_list.#", "25"),
+					testAccCheckTseGatewayRoutesAllListed("data.tencentcloud_tse_gateway_routes.gateway_routes"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckTseGatewayRoutesAllListed(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("data source %s is not found", n)
+		}
+		total := rs.Primary.Attributes["result.0.total_count"]
+		listed := rs.Primary.Attributes["result.0.route_list.#"]
+		if total != listed {
+			return fmt.Errorf("total_count is %s, but %s routes are listed", total, listed)
+		}
+		return nil
+	}
+}
+
 const testAccTseGatewayRoutesDataSource = `
 
 data "tencentcloud_tse_gateway_routes" "gateway_routes" {
@@ -52,3 +89,24 @@ data "tencentcloud_tse_gateway_routes" "gateway_routes" {
 }
 
 `
+
+const testAccTseGatewayRoutesDataSourcePagination = testAccTseCngwRouteService + `
+
+resource "tencentcloud_tse_cngw_route" "cngw_route" {
+  count      = 25
+  gateway_id = var.gateway_id
+  service_id = tencentcloud_tse_cngw_service.cngw_service.service_id
+  route_name = "terraform-route-${count.index}"
+  methods    = ["GET"]
+  paths      = ["/user${count.index}"]
+  protocols  = ["http"]
+}
+
+data "tencentcloud_tse_gateway_routes" "gateway_routes" {
+  gateway_id   = var.gateway_id
+  service_name = tencentcloud_tse_cngw_service.cngw_service.name
+
+  depends_on = [tencentcloud_tse_cngw_route.cngw_route]
+}
+
+`
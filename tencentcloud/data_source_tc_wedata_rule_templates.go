@@ -0,0 +1,229 @@
+/*
+Use this data source to query detailed information of wedata rule_templates
+
+Example Usage
+
+```hcl
+data "tencentcloud_wedata_rule_templates" "rule_templates" {
+  project_id  = "1840731346428280832"
+  type        = 2
+  quality_dim = 3
+}
+```
+*/
+package tencentcloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	wedata "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/wedata/v20210820"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func dataSourceTencentCloudWedataRuleTemplates() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTencentCloudWedataRuleTemplatesRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Required:    true,
+				Type:        schema.TypeString,
+				Description: "Project ID.",
+			},
+
+			"type": {
+				Optional:    true,
+				Type:        schema.TypeInt,
+				Description: "Template type. `1` means system template, `2` means custom template.",
+			},
+
+			"quality_dim": {
+				Optional:    true,
+				Type:        schema.TypeInt,
+				Description: "Quality dimension. `1` accuracy, `2` uniqueness, `3` completeness, `4` consistency, `5` timeliness, `6` effectiveness.",
+			},
+
+			"data": {
+				Computed:    true,
+				Type:        schema.TypeList,
+				Description: "Rule templates.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rule_template_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Rule template ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the rule template.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the rule template.",
+						},
+						"type": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Template type. `1` means system template, `2` means custom template.",
+						},
+						"quality_dim": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Quality dimension of the rule template.",
+						},
+						"source_object_type": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Source object type. `1` constant, `2` offline table level, `3` offline field level.",
+						},
+						"source_engine_types": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "Source engine types.",
+						},
+						"multi_source_flag": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether multiple tables are associated.",
+						},
+						"sql_expression": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "SQL expression of the custom template.",
+						},
+						"where_flag": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the where parameter is added.",
+						},
+						"update_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Update time of the rule template.",
+						},
+					},
+				},
+			},
+
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+		},
+	}
+}
+
+func dataSourceTencentCloudWedataRuleTemplatesRead(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("data_source.tencentcloud_wedata_rule_templates.read")()
+	defer inconsistentCheck(d, meta)()
+
+	logId := getLogId(contextNil)
+
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+	paramMap := make(map[string]interface{})
+	if v, ok := d.GetOk("project_id"); ok {
+		paramMap["ProjectId"] = helper.String(v.(string))
+	}
+
+	service := WedataService{client: meta.(*TencentCloudClient).apiV3Conn}
+
+	var ruleTemplates []*wedata.RuleTemplate
+	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		result, e := service.DescribeWedataRuleTemplatesByFilter(ctx, paramMap)
+		if e != nil {
+			return retryError(e)
+		}
+		ruleTemplates = result
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// the page API takes no typed filters, so type and quality_dim are matched here
+	templateType, hasType := d.GetOk("type")
+	qualityDim, hasQualityDim := d.GetOk("quality_dim")
+
+	ids := make([]string, 0, len(ruleTemplates))
+	tmpList := make([]map[string]interface{}, 0, len(ruleTemplates))
+	for _, ruleTemplate := range ruleTemplates {
+		if ruleTemplate.RuleTemplateId == nil {
+			continue
+		}
+		if hasType && (ruleTemplate.Type == nil || int(*ruleTemplate.Type) != templateType.(int)) {
+			continue
+		}
+		if hasQualityDim && (ruleTemplate.QualityDim == nil || int(*ruleTemplate.QualityDim) != qualityDim.(int)) {
+			continue
+		}
+
+		ruleTemplateMap := map[string]interface{}{
+			"rule_template_id": ruleTemplate.RuleTemplateId,
+		}
+
+		if ruleTemplate.Name != nil {
+			ruleTemplateMap["name"] = ruleTemplate.Name
+		}
+
+		if ruleTemplate.Description != nil {
+			ruleTemplateMap["description"] = ruleTemplate.Description
+		}
+
+		if ruleTemplate.Type != nil {
+			ruleTemplateMap["type"] = ruleTemplate.Type
+		}
+
+		if ruleTemplate.QualityDim != nil {
+			ruleTemplateMap["quality_dim"] = ruleTemplate.QualityDim
+		}
+
+		if ruleTemplate.SourceObjectType != nil {
+			ruleTemplateMap["source_object_type"] = ruleTemplate.SourceObjectType
+		}
+
+		if ruleTemplate.SourceEngineTypes != nil {
+			sourceEngineTypes := make([]int, 0, len(ruleTemplate.SourceEngineTypes))
+			for _, sourceEngineType := range ruleTemplate.SourceEngineTypes {
+				sourceEngineTypes = append(sourceEngineTypes, int(*sourceEngineType))
+			}
+			ruleTemplateMap["source_engine_types"] = sourceEngineTypes
+		}
+
+		if ruleTemplate.MultiSourceFlag != nil {
+			ruleTemplateMap["multi_source_flag"] = ruleTemplate.MultiSourceFlag
+		}
+
+		if ruleTemplate.SqlExpression != nil {
+			ruleTemplateMap["sql_expression"] = ruleTemplate.SqlExpression
+		}
+
+		if ruleTemplate.WhereFlag != nil {
+			ruleTemplateMap["where_flag"] = ruleTemplate.WhereFlag
+		}
+
+		if ruleTemplate.UpdateTime != nil {
+			ruleTemplateMap["update_time"] = ruleTemplate.UpdateTime
+		}
+
+		ids = append(ids, helper.UInt64ToStr(*ruleTemplate.RuleTemplateId))
+		tmpList = append(tmpList, ruleTemplateMap)
+	}
+
+	_ = d.Set("data", tmpList)
+
+	d.SetId(helper.DataResourceIdsHash(ids))
+	output, ok := d.GetOk("result_output_file")
+	if ok && output.(string) != "" {
+		if e := writeToFile(output.(string), tmpList); e != nil {
+			return e
+		}
+	}
+	return nil
+}
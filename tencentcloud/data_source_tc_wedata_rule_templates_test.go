@@ -0,0 +1,40 @@
+package tencentcloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// go test -i; go test -test.run TestAccTencentCloudWedataRuleTemplatesDataSource_basic -v
+func TestAccTencentCloudWedataRuleTemplatesDataSource_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWedataRuleTemplatesDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTencentCloudDataSourceID("data.tencentcloud_wedata_rule_templates.rule_templates"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_wedata_rule_templates.rule_templates", "data.#"),
+					resource.TestCheckResourceAttr("data.tencentcloud_wedata_rule_templates.rule_templates", "data.0.type", "2"),
+					resource.TestCheckResourceAttr("data.tencentcloud_wedata_rule_templates.rule_templates", "data.0.quality_dim", "3"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_wedata_rule_templates.rule_templates", "data.0.rule_template_id"),
+					resource.TestCheckResourceAttrSet("data.tencentcloud_wedata_rule_templates.rule_templates", "data.0.name"),
+				),
+			},
+		},
+	})
+}
+
+const testAccWedataRuleTemplatesDataSource = testAccWedataRuleTemplate + `
+
+data "tencentcloud_wedata_rule_templates" "rule_templates" {
+  project_id  = tencentcloud_wedata_rule_template.rule_template.project_id
+  type        = 2
+  quality_dim = 3
+}
+`
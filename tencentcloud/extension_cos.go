@@ -1,5 +1,16 @@
 package tencentcloud
 
+import "time"
+
+// COS_INVENTORY_READY_TIMEOUT bounds the wait for a put inventory to be retrievable, since PutBucketInventory is eventually consistent.
+const COS_INVENTORY_READY_TIMEOUT = 30 * time.Second
+
+// COS_INVENTORY_FORMAT_FREQUENCIES lists the schedule frequencies COS supports for each inventory result format,
+// a format which is not listed is passed to the API as it is.
+var COS_INVENTORY_FORMAT_FREQUENCIES = map[string][]string{
+	"CSV": {"Daily", "Weekly"},
+}
+
 const (
 	COS_ACL_GRANTEE_TYPE_USER      = "CanonicalUser"
 	COS_ACL_GRANTEE_TYPE_ANONYMOUS = "Group"
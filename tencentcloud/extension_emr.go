@@ -12,7 +12,23 @@ const (
 )
 
 const (
-	DisplayStrategyIsclusterList = "clusterList"
+	// EmrClusterStateNotFound is not returned by the API, it marks a cluster that can not be found.
+	EmrClusterStateNotFound = "NOT_FOUND"
+)
+
+const (
+	DisplayStrategyIsclusterList   = "clusterList"
+	DisplayStrategyIsmonitorManage = "monitorManage"
+)
+
+var EMR_DISPLAY_STRATEGIES = []string{DisplayStrategyIsclusterList, DisplayStrategyIsmonitorManage}
+
+const (
+	EMR_NODE_FLAG_ALL               = "all"
+	EMR_NODE_FLAG_MASTER            = "master"
+	EMR_NODE_FLAG_TASK              = "task"
+	EMR_HARDWARE_RESOURCE_TYPE_ALL  = "all"
+	EMR_DESCRIBE_CLUSTER_NODE_LIMIT = 100
 )
 
 const (
@@ -22,11 +38,80 @@ const (
 
 var EMR_MASTER_WAN_TYPES = []string{EMR_MASTER_WAN_TYPE_NEED_MASTER_WAN, EMR_MASTER_WAN_TYPE_NOT_NEED_MASTER_WAN}
 
+const (
+	EMR_META_TYPE_NEW_META    = "EMR_NEW_META"
+	EMR_META_TYPE_EXIT_META   = "EMR_EXIT_META"
+	EMR_META_TYPE_CUSTOM_META = "USER_CUSTOM_META"
+)
+
+var EMR_META_TYPES = []string{EMR_META_TYPE_NEW_META, EMR_META_TYPE_EXIT_META, EMR_META_TYPE_CUSTOM_META}
+
+const (
+	EMR_VPC_SETTINGS_KEY_VPC_ID    = "vpc_id"
+	EMR_VPC_SETTINGS_KEY_SUBNET_ID = "subnet_id"
+)
+
+var EMR_VPC_SETTINGS_KEYS = []string{EMR_VPC_SETTINGS_KEY_VPC_ID, EMR_VPC_SETTINGS_KEY_SUBNET_ID}
+
+// EMR_CBS_ENCRYPT_UNSUPPORTED_DISK_TYPES are local disks, which can not be encrypted by CBS.
+var EMR_CBS_ENCRYPT_UNSUPPORTED_DISK_TYPES = []string{"LOCAL_BASIC", "LOCAL_SSD", "LOCAL_PRO"}
+
+// EMR_SOFTWARE_UNAVAILABLE_ERROR_CODES are returned by the price inquiry when the softwares can not be deployed in the zone.
+var EMR_SOFTWARE_UNAVAILABLE_ERROR_CODES = []string{
+	"InvalidParameter.InvalidSoftWare",
+	"InvalidParameter.InvalidSoftWareName",
+	"InvalidParameter.InvalidSoftWareVersion",
+	"InvalidParameter.NotContainMustSelectSoftware",
+	"InvalidParameter.SoftwareNotInProduct",
+	"InvalidParameter.InvalidZone",
+	"InvalidParameter.ZoneResourceNotMatch",
+}
+
+const (
+	EMR_PRODUCT_FAMILY_HADOOP    = "Hadoop"
+	EMR_PRODUCT_FAMILY_KAFKA     = "Kafka"
+	EMR_PRODUCT_FAMILY_STARROCKS = "StarRocks"
+	EMR_PRODUCT_FAMILY_DRUID     = "Druid"
+)
+
+// EMR_PRODUCT_FAMILIES maps the documented product_id values to their product families.
+var EMR_PRODUCT_FAMILIES = map[int]string{
+	16: EMR_PRODUCT_FAMILY_HADOOP,
+	20: EMR_PRODUCT_FAMILY_HADOOP,
+	25: EMR_PRODUCT_FAMILY_HADOOP,
+	27: EMR_PRODUCT_FAMILY_KAFKA,
+	30: EMR_PRODUCT_FAMILY_HADOOP,
+	33: EMR_PRODUCT_FAMILY_HADOOP,
+	34: EMR_PRODUCT_FAMILY_HADOOP,
+	36: EMR_PRODUCT_FAMILY_STARROCKS,
+	37: EMR_PRODUCT_FAMILY_HADOOP,
+	38: EMR_PRODUCT_FAMILY_HADOOP,
+	39: EMR_PRODUCT_FAMILY_STARROCKS,
+	41: EMR_PRODUCT_FAMILY_DRUID,
+}
+
+// EMR_PRODUCT_FAMILY_COMPONENTS are the components a product family can deploy,
+// the softwares of a family which is not listed are not checked.
+var EMR_PRODUCT_FAMILY_COMPONENTS = map[string][]string{
+	EMR_PRODUCT_FAMILY_KAFKA:     {"kafka", "zookeeper"},
+	EMR_PRODUCT_FAMILY_STARROCKS: {"starrocks"},
+}
+
+// EMR_RESOURCE_SPEC_REPLACE_KEYS are the resource_spec fields which can not be changed online,
+// changing them replaces the cluster. The node counts not listed here are scaled online.
+var EMR_RESOURCE_SPEC_REPLACE_KEYS = []string{
+	"master_resource_spec",
+	"core_resource_spec",
+	"task_resource_spec",
+	"common_resource_spec",
+	"common_count",
+}
+
+// buildResourceSpecSchema is not ForceNew, the replacement is decided by resourceTencentCloudEmrClusterResourceSpecDiff.
 func buildResourceSpecSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeList,
 		Optional: true,
-		ForceNew: true,
 		MaxItems: 1,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
@@ -78,6 +163,38 @@ func ParseTags(_tags []map[string]string) []*emr.Tag {
 	return tags
 }
 
+func ParseResourceSpec(resourceSpec map[string]interface{}) *emr.NewResourceSpec {
+	result := &emr.NewResourceSpec{}
+	for k, v := range resourceSpec {
+		if k == "master_resource_spec" {
+			if len(v.([]interface{})) > 0 {
+				result.MasterResourceSpec = ParseResource(v.([]interface{})[0].(map[string]interface{}))
+			}
+		} else if k == "core_resource_spec" {
+			if len(v.([]interface{})) > 0 {
+				result.CoreResourceSpec = ParseResource(v.([]interface{})[0].(map[string]interface{}))
+			}
+		} else if k == "task_resource_spec" {
+			if len(v.([]interface{})) > 0 {
+				result.TaskResourceSpec = ParseResource(v.([]interface{})[0].(map[string]interface{}))
+			}
+		} else if k == "master_count" {
+			result.MasterCount = common.Int64Ptr((int64)(v.(int)))
+		} else if k == "core_count" {
+			result.CoreCount = common.Int64Ptr((int64)(v.(int)))
+		} else if k == "task_count" {
+			result.TaskCount = common.Int64Ptr((int64)(v.(int)))
+		} else if k == "common_resource_spec" {
+			if len(v.([]interface{})) > 0 {
+				result.CommonResourceSpec = ParseResource(v.([]interface{})[0].(map[string]interface{}))
+			}
+		} else if k == "common_count" {
+			result.CommonCount = common.Int64Ptr((int64)(v.(int)))
+		}
+	}
+	return result
+}
+
 func ParseResource(_resource map[string]interface{}) *emr.Resource {
 	resultResource := &emr.Resource{}
 	for k, v := range _resource {
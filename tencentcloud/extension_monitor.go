@@ -84,3 +84,9 @@ var MonitorRegionMap = map[string]string{
 	"eu-frankfurt":       "de",
 	"eu-moscow":          "ru",
 }
+
+// prometheus cvm agent status
+const (
+	MONITOR_TMP_CVM_AGENT_STATUS_ENABLED  = 1
+	MONITOR_TMP_CVM_AGENT_STATUS_DISABLED = 2
+)
@@ -44,3 +44,10 @@ var POSTGRESQL_RETRYABLE_STATUS = []string{
 	// deployment changing not exposed at response struct but actually exists
 	"deployment changing",
 }
+
+var POSTGRESQL_PARAMETER_TEMPLATE_FILTER_NAMES = []string{
+	"TemplateName",
+	"TemplateId",
+	"DBMajorVersion",
+	"DBEngine",
+}
@@ -3,6 +3,8 @@ package tencentcloud
 const (
 	SCF_FUNCTION_TYPE_EVENT = "Event"
 
+	SCF_FUNCTION_QUALIFIER_LATEST = "$LATEST"
+
 	SCF_FUNCTION_STATUS_ACTIVE         = "Active"
 	SCF_FUNCTION_STATUS_CREATING       = "Creating"
 	SCF_FUNCTION_STATUS_CREATE_FAILED  = "CreateFailed"
@@ -75,3 +77,13 @@ var (
 		SCF_LOGS_RET_CODE_USER_CODE_EXCEPTION,
 	}
 )
+
+const (
+	// SCF_ASYNC_RETRY_TTL_PER_RETRY is the default seconds of msg_ttl each async retry is expected to need
+	SCF_ASYNC_RETRY_TTL_PER_RETRY = 60
+	// PROVIDER_SCF_ASYNC_RETRY_TTL_PER_RETRY overrides SCF_ASYNC_RETRY_TTL_PER_RETRY
+	PROVIDER_SCF_ASYNC_RETRY_TTL_PER_RETRY = "TENCENTCLOUD_SCF_ASYNC_RETRY_TTL_PER_RETRY"
+	// SCF_ASYNC_DEFAULT_RETRY_NUM and SCF_ASYNC_DEFAULT_MSG_TTL are the async trigger config of a function which is never configured
+	SCF_ASYNC_DEFAULT_RETRY_NUM = 2
+	SCF_ASYNC_DEFAULT_MSG_TTL   = 21600
+)
@@ -41,6 +41,9 @@ const (
 	SQLSERVER_DEFAULT_OFFSET = 0
 )
 
+// SQLSERVER_INS_ATTRIBUTE_CONCURRENCY limits concurrent DescribeDBInstancesAttribute calls of one read
+const SQLSERVER_INS_ATTRIBUTE_CONCURRENCY = 5
+
 const (
 	SQLSERVER_DB_CREATING         = 1
 	SQLSERVER_DB_RUNNING          = 2
@@ -13,3 +13,17 @@ const (
 const (
 	SSMResourceNotFound = "ResourceNotFound"
 )
+
+const (
+	SSM_PRIVILEGE_GLOBAL   = "GlobalPrivileges"
+	SSM_PRIVILEGE_DATABASE = "DatabasePrivileges"
+	SSM_PRIVILEGE_TABLE    = "TablePrivileges"
+	SSM_PRIVILEGE_COLUMN   = "ColumnPrivileges"
+)
+
+var SSM_PRIVILEGE_NAMES = []string{
+	SSM_PRIVILEGE_GLOBAL,
+	SSM_PRIVILEGE_DATABASE,
+	SSM_PRIVILEGE_TABLE,
+	SSM_PRIVILEGE_COLUMN,
+}
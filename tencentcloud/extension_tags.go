@@ -1,3 +1,6 @@
 package tencentcloud
 
 const DESCRIBE_TAGS_LIMIT = 20
+
+// TAG_AUTH_ERROR_CODES are returned when the caller lacks permission on the tag service.
+var TAG_AUTH_ERROR_CODES = []string{"UnauthorizedOperation", "AuthFailure"}
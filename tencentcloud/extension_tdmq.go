@@ -1,5 +1,7 @@
 package tencentcloud
 
+import "time"
+
 const (
 	NoneTopicType       = -1
 	NonePulsarTopicType = -1
@@ -14,6 +16,9 @@ const (
 	AutoRenewFlagTrue = 1
 )
 
+// RabbitMQUserCreateWindow is how long a freshly created rabbitmq user may still be reported as not found
+const RabbitMQUserCreateWindow = 15 * time.Second
+
 const (
 	RocketMqVipInsRunning   = 0
 	RocketMqVipInsSuccess   = 1
@@ -21,3 +26,8 @@ const (
 	RocketMqVipInsDestroy   = 3
 	RocketMqVipInsUpdate    = 6
 )
+
+var TDMQ_NAMESPACE_ROLE_PERMISSIONS = []string{
+	"produce",
+	"consume",
+}
@@ -0,0 +1,9 @@
+package tencentcloud
+
+const (
+	TSE_PLUGIN_NAME_RATE_LIMITING = "rate-limiting"
+)
+
+// TSE_GATEWAY_NOT_READY_ERROR_CODES are returned while the gateway is still provisioning, a missing gateway
+// returns ResourceNotFound instead and is not retried.
+var TSE_GATEWAY_NOT_READY_ERROR_CODES = []string{"OperationDenied", "InternalError.HttpStatusCodeError"}
@@ -116,9 +116,24 @@ const (
 )
 
 const (
-	NAT_FAILED_STATE = "FAILED"
+	NAT_AVAILABLE_STATE = "AVAILABLE"
+	NAT_FAILED_STATE    = "FAILED"
+	// NAT_NOT_FOUND_STATE is not returned by the API, it marks a NAT gateway that can not be found.
+	NAT_NOT_FOUND_STATE = "NOT_FOUND"
 )
 
+// NAT_BANDWIDTH_CHECK_MINUTES is how many recent minutes of flow monitor data are checked
+// before lowering the bandwidth of a NAT gateway.
+const NAT_BANDWIDTH_CHECK_MINUTES = 5
+
+const (
+	NAT_PRODUCT_VERSION_TRADITIONAL = 1
+	NAT_PRODUCT_VERSION_STANDARD    = 2
+)
+
+// NAT_DEFAULT_ISP is the line type of a NAT gateway whose EIPs report no ISP
+const NAT_DEFAULT_ISP = "BGP"
+
 const (
 	NAT_GATEWAY_TYPE_SUBNET            = "SUBNET"
 	NAT_GATEWAY_TYPE_NETWORK_INTERFACE = "NETWORKINTERFACE"
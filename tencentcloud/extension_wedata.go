@@ -0,0 +1,8 @@
+package tencentcloud
+
+const (
+	WEDATA_SQL_EXPRESSION_ENCODING_BASE64    = "base64"
+	WEDATA_SQL_EXPRESSION_ENCODING_PLAINTEXT = "plaintext"
+)
+
+var WEDATA_SQL_EXPRESSION_ENCODINGS = []string{WEDATA_SQL_EXPRESSION_ENCODING_BASE64, WEDATA_SQL_EXPRESSION_ENCODING_PLAINTEXT}
@@ -1,6 +1,9 @@
 package helper
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 const connect = "#"
 
@@ -11,3 +14,29 @@ func IdFormat(s ...string) string {
 func IdParse(s string) []string {
 	return strings.Split(s, connect)
 }
+
+// ParseCompositeId splits a composite id made by IdFormat into exactly n non-empty segments.
+// The optional names describe the segments, and are used to report the expected format.
+func ParseCompositeId(id string, n int, names ...string) ([]string, error) {
+	segments := IdParse(id)
+	if len(segments) == n {
+		valid := true
+		for _, segment := range segments {
+			if segment == "" {
+				valid = false
+				break
+			}
+		}
+		if valid {
+			return segments, nil
+		}
+	}
+
+	if len(names) != n {
+		names = make([]string, n)
+		for i := range names {
+			names[i] = fmt.Sprintf("<part%d>", i+1)
+		}
+	}
+	return nil, fmt.Errorf("id `%s` is broken, expected format is `%s`", id, IdFormat(names...))
+}
@@ -0,0 +1,36 @@
+package helper
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCompositeId(t *testing.T) {
+	segments, err := ParseCompositeId("amqp-xxxxxxxx#user", 2, "instanceId", "user")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(segments, []string{"amqp-xxxxxxxx", "user"}) {
+		t.Fatalf("unexpected segments %v", segments)
+	}
+}
+
+func TestParseCompositeIdMalformed(t *testing.T) {
+	for _, id := range []string{"", "amqp-xxxxxxxx", "amqp-xxxxxxxx#", "#user", "amqp-xxxxxxxx#user#extra"} {
+		_, err := ParseCompositeId(id, 2, "instanceId", "user")
+		if err == nil {
+			t.Fatalf("expected error for id %q", id)
+		}
+		if !strings.Contains(err.Error(), "instanceId#user") {
+			t.Fatalf("expected the format in error, got %v", err)
+		}
+	}
+}
+
+func TestParseCompositeIdDefaultFormat(t *testing.T) {
+	_, err := ParseCompositeId("a#b", 3)
+	if err == nil || !strings.Contains(err.Error(), "<part1>#<part2>#<part3>") {
+		t.Fatalf("expected the default format in error, got %v", err)
+	}
+}
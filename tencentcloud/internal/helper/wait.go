@@ -0,0 +1,37 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// WaitForState polls describe until it reports one of targetStates.
+//
+// It returns an error as soon as describe fails, a state in failStates is
+// reached, the timeout expires or ctx is cancelled. Any other state is treated as pending.
+func WaitForState(ctx context.Context, describe func() (string, error), targetStates, failStates []string, timeout time.Duration) error {
+	conf := &resource.StateChangeConf{
+		Target:  targetStates,
+		Timeout: timeout,
+		Refresh: func() (interface{}, string, error) {
+			// do not describe again once the operation is cancelled
+			if err := ctx.Err(); err != nil {
+				return nil, "", err
+			}
+			state, err := describe()
+			if err != nil {
+				return nil, "", err
+			}
+			if StringsContain(failStates, state) {
+				return state, state, fmt.Errorf("unexpected state `%s`, wanted `%v`", state, targetStates)
+			}
+			return state, state, nil
+		},
+	}
+
+	_, err := conf.WaitForStateContext(ctx)
+	return err
+}
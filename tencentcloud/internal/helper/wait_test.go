@@ -0,0 +1,79 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitForState(t *testing.T) {
+	states := []string{"PENDING", "PENDING", "AVAILABLE"}
+	calls := 0
+	describe := func() (string, error) {
+		state := states[calls]
+		calls++
+		return state, nil
+	}
+
+	err := WaitForState(context.TODO(), describe, []string{"AVAILABLE"}, []string{"FAILED"}, time.Minute)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if calls != len(states) {
+		t.Fatalf("expected %d describe calls, got %d", len(states), calls)
+	}
+}
+
+func TestWaitForStateFailState(t *testing.T) {
+	describe := func() (string, error) {
+		return "FAILED", nil
+	}
+
+	err := WaitForState(context.TODO(), describe, []string{"AVAILABLE"}, []string{"FAILED"}, time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "FAILED") {
+		t.Fatalf("expected fail state error, got %v", err)
+	}
+}
+
+func TestWaitForStateDescribeError(t *testing.T) {
+	describeErr := errors.New("describe failed")
+	describe := func() (string, error) {
+		return "", describeErr
+	}
+
+	err := WaitForState(context.TODO(), describe, []string{"AVAILABLE"}, nil, time.Minute)
+	if !errors.Is(err, describeErr) {
+		t.Fatalf("expected describe error, got %v", err)
+	}
+}
+
+func TestWaitForStateTimeout(t *testing.T) {
+	describe := func() (string, error) {
+		return "PENDING", nil
+	}
+
+	err := WaitForState(context.TODO(), describe, []string{"AVAILABLE"}, nil, 500*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected timeout error, got nil")
+	}
+}
+
+func TestWaitForStateCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	calls := 0
+	describe := func() (string, error) {
+		calls++
+		cancel()
+		return "PENDING", nil
+	}
+
+	err := WaitForState(ctx, describe, []string{"AVAILABLE"}, nil, time.Minute)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled error, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 describe call, got %d", calls)
+	}
+}
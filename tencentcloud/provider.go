@@ -790,6 +790,8 @@ Serverless Cloud Function(SCF)
 	tencentcloud_scf_layer_versions
 	tencentcloud_scf_layers
 	tencentcloud_scf_function_versions
+	tencentcloud_scf_function_event_invoke_configs
+	tencentcloud_scf_function_event_invoke_config
 
   Resource
     tencentcloud_scf_function
@@ -829,6 +831,7 @@ SQLServer
 	tencentcloud_sqlserver_upload_incremental_info
 	tencentcloud_sqlserver_query_xevent
 	tencentcloud_sqlserver_ins_attribute
+	tencentcloud_sqlserver_ins_attributes
 
   Resource
 	tencentcloud_sqlserver_instance
@@ -881,6 +884,7 @@ Secrets Manager(SSM)
 	tencentcloud_ssm_products
     tencentcloud_ssm_secrets
     tencentcloud_ssm_secret_versions
+    tencentcloud_ssm_product_secret
 
   Resource
     tencentcloud_ssm_secret
@@ -1072,6 +1076,8 @@ MapReduce(EMR)
   Data Source
     tencentcloud_emr
     tencentcloud_emr_nodes
+    tencentcloud_emr_cluster_config
+    tencentcloud_emr_cluster
 
   Resource
     tencentcloud_emr_cluster
@@ -1570,11 +1576,13 @@ Tencent Cloud Service Engine(TSE)
 	tencentcloud_tse_gateway_nodes
 	tencentcloud_tse_gateway_canary_rules
 	tencentcloud_tse_gateway_services
+	tencentcloud_tse_gateway_route_plugins
 
   Resource
 	tencentcloud_tse_instance
 	tencentcloud_tse_cngw_service
 	tencentcloud_tse_cngw_canary_rule
+	tencentcloud_tse_cngw_route
 
 ClickHouse(CDWCH)
   Resource
@@ -1600,6 +1608,13 @@ Data Lake Compute(DLC)
   Resource
 	tencentcloud_dlc_work_group
 	tencentcloud_dlc_user
+
+WeData
+  Data Source
+	tencentcloud_wedata_rule_templates
+
+  Resource
+	tencentcloud_wedata_rule_template
 */
 package tencentcloud
 
@@ -1633,7 +1648,7 @@ type TencentCloudClient struct {
 }
 
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"secret_id": {
 				Type:        schema.TypeString,
@@ -1720,6 +1735,8 @@ func Provider() *schema.Provider {
 			"tencentcloud_availability_regions":                      dataSourceTencentCloudAvailabilityRegions(),
 			"tencentcloud_emr":                                       dataSourceTencentCloudEmr(),
 			"tencentcloud_emr_nodes":                                 dataSourceTencentCloudEmrNodes(),
+			"tencentcloud_emr_cluster_config":                        dataSourceTencentCloudEmrClusterConfig(),
+			"tencentcloud_emr_cluster":                               dataSourceTencentCloudEmrCluster(),
 			"tencentcloud_availability_zones":                        dataSourceTencentCloudAvailabilityZones(),
 			"tencentcloud_availability_zones_by_product":             dataSourceTencentCloudAvailabilityZonesByProduct(),
 			"tencentcloud_projects":                                  dataSourceTencentCloudProjects(),
@@ -1929,6 +1946,8 @@ func Provider() *schema.Provider {
 			"tencentcloud_scf_layer_versions":                        dataSourceTencentCloudScfLayerVersions(),
 			"tencentcloud_scf_layers":                                dataSourceTencentCloudScfLayers(),
 			"tencentcloud_scf_function_versions":                     dataSourceTencentCloudScfFunctionVersions(),
+			"tencentcloud_scf_function_event_invoke_configs":         dataSourceTencentCloudScfFunctionEventInvokeConfigs(),
+			"tencentcloud_scf_function_event_invoke_config":          dataSourceTencentCloudScfFunctionEventInvokeConfig(),
 			"tencentcloud_scf_logs":                                  dataSourceTencentCloudScfLogs(),
 			"tencentcloud_tcaplus_clusters":                          dataSourceTencentCloudTcaplusClusters(),
 			"tencentcloud_tcaplus_tablegroups":                       dataSourceTencentCloudTcaplusTableGroups(),
@@ -2018,6 +2037,7 @@ func Provider() *schema.Provider {
 			"tencentcloud_sqlserver_basic_instances":                 dataSourceTencentCloudSqlserverBasicInstances(),
 			"tencentcloud_sqlserver_query_xevent":                    dataSourceTencentCloudSqlserverQueryXevent(),
 			"tencentcloud_sqlserver_ins_attribute":                   dataSourceTencentCloudSqlserverInsAttribute(),
+			"tencentcloud_sqlserver_ins_attributes":                  dataSourceTencentCloudSqlserverInsAttributes(),
 			"tencentcloud_tcr_instances":                             dataSourceTencentCloudTCRInstances(),
 			"tencentcloud_tcr_namespaces":                            dataSourceTencentCloudTCRNamespaces(),
 			"tencentcloud_tcr_tokens":                                dataSourceTencentCloudTCRTokens(),
@@ -2038,6 +2058,7 @@ func Provider() *schema.Provider {
 			"tencentcloud_ssm_products":                              dataSourceTencentCloudSsmProducts(),
 			"tencentcloud_ssm_secrets":                               dataSourceTencentCloudSsmSecrets(),
 			"tencentcloud_ssm_secret_versions":                       dataSourceTencentCloudSsmSecretVersions(),
+			"tencentcloud_ssm_product_secret":                        dataSourceTencentCloudSsmProductSecret(),
 			"tencentcloud_cdh_instances":                             dataSourceTencentCloudCdhInstances(),
 			"tencentcloud_dayu_eip":                                  dataSourceTencentCloudDayuEip(),
 			"tencentcloud_teo_zone_available_plans":                  dataSourceTencentCloudTeoZoneAvailablePlans(),
@@ -2221,6 +2242,7 @@ func Provider() *schema.Provider {
 			"tencentcloud_tse_gateway_routes":                        dataSourceTencentCloudTseGatewayRoutes(),
 			"tencentcloud_tse_gateway_canary_rules":                  dataSourceTencentCloudTseGatewayCanaryRules(),
 			"tencentcloud_tse_gateway_services":                      dataSourceTencentCloudTseGatewayServices(),
+			"tencentcloud_tse_gateway_route_plugins":                 dataSourceTencentCloudTseGatewayRoutePlugins(),
 			"tencentcloud_lighthouse_modify_instance_bundle":         dataSourceTencentCloudLighthouseModifyInstanceBundle(),
 			"tencentcloud_lighthouse_zone":                           dataSourceTencentCloudLighthouseZone(),
 			"tencentcloud_lighthouse_scene":                          dataSourceTencentCloudLighthouseScene(),
@@ -2238,6 +2260,7 @@ func Provider() *schema.Provider {
 			"tencentcloud_cls_machine_group_configs":                 dataSourceTencentCloudClsMachineGroupConfigs(),
 			"tencentcloud_eb_search":                                 dataSourceTencentCloudEbSearch(),
 			"tencentcloud_eb_bus":                                    dataSourceTencentCloudEbBus(),
+			"tencentcloud_wedata_rule_templates":                     dataSourceTencentCloudWedataRuleTemplates(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -2974,6 +2997,12 @@ func Provider() *schema.Provider {
 
 		ConfigureFunc: providerConfigure,
 	}
+
+	for _, dataSource := range provider.DataSourcesMap {
+		withResultOutputMetadata(dataSource)
+	}
+
+	return provider
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
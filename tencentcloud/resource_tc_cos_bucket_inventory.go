@@ -30,6 +30,28 @@ resource "tencentcloud_cos_bucket_inventory" "bucket_inventory" {
 }
 ```
 
+Cover several prefixes, an inventory is managed for each of them
+
+```hcl
+resource "tencentcloud_cos_bucket_inventory" "prefixes" {
+  name                     = "multi"
+  bucket                   = "keep-test-xxxxxx"
+  is_enabled               = "true"
+  included_object_versions = "Current"
+  filter {
+    prefixes = ["logs/", "images/"]
+  }
+  schedule {
+    frequency = "Daily"
+  }
+  destination {
+    bucket = "qcs::cos:ap-guangzhou::keep-test-xxxxxx"
+    format = "CSV"
+    prefix = "cos_bucket_inventory"
+  }
+}
+```
+
 Import
 
 cos bucket_inventory can be imported using the id, e.g.
@@ -37,6 +59,8 @@ cos bucket_inventory can be imported using the id, e.g.
 ```
 terraform import tencentcloud_cos_bucket_inventory.bucket_inventory bucket_inventory_id
 ```
+
+-> **Note:** The inventories derived from `prefixes` can not be imported.
 */
 package tencentcloud
 
@@ -44,7 +68,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"hash/crc32"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -62,12 +88,14 @@ func resourceTencentCloudCosBucketInventory() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceTencentCloudCosBucketInventoryScheduleDiff,
 		Schema: map[string]*schema.Schema{
 			"bucket": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Bucket name.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCosBucketName,
+				Description:  "Bucket name. Bucket format should be [custom name]-[appid], for example `mycos-1258798060`.",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -93,9 +121,22 @@ func resourceTencentCloudCosBucketInventory() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"prefix": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							ConflictsWith: []string{"filter.0.prefixes"},
+							Description:   "Prefix of the objects to analyze.",
+						},
+						"prefixes": {
+							Type:          schema.TypeSet,
+							Elem:          &schema.Schema{Type: schema.TypeString},
+							Optional:      true,
+							ConflictsWith: []string{"filter.0.prefix"},
+							Description:   "Prefixes of the objects to analyze. COS inventory takes only one prefix, so an inventory named `<name>-<crc32 of prefix>` is managed for each prefix instead of the inventory `name`, it fails when such an inventory already exists.",
+						},
+						"storage_class": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							Description: "Prefix of the objects to analyze.",
+							Description: "Storage classes of the objects to analyze, multiple classes are separated by commas, such as `Standard,StandardIA,Archive`. It can be combined with `prefix`.",
 						},
 						"period": {
 							Type:        schema.TypeList,
@@ -148,7 +189,7 @@ func resourceTencentCloudCosBucketInventory() *schema.Resource {
 						"frequency": {
 							Type:        schema.TypeString,
 							Required:    true,
-							Description: "Frequency of the inventory job. Enumerated values: Daily, Weekly.",
+							Description: "Frequency of the inventory job. Enumerated values: Daily, Weekly. It must be supported by the `format` of `destination`.",
 						},
 					},
 				},
@@ -210,105 +251,35 @@ func resourceTencentCloudCosBucketInventoryCreate(d *schema.ResourceData, meta i
 	ctx := context.WithValue(context.TODO(), logIdKey, logId)
 	bucket := d.Get("bucket").(string)
 	name := d.Get("name").(string)
-	isEnabled := d.Get("is_enabled").(string)
-	includedObjectVersions := d.Get("included_object_versions").(string)
 
-	var filter cos.BucketInventoryFilter
-	if v, ok := d.GetOk("filter"); ok && len(v.([]interface{})) != 0 {
-		var period cos.BucketInventoryFilterPeriod
-		filterMap := v.([]interface{})[0].(map[string]interface{})
-		if v, ok := filterMap["period"]; ok && len(v.([]interface{})) > 0 {
-			periodMap := v.([]interface{})[0].(map[string]interface{})
-			if v, ok := periodMap["start_time"]; ok && v.(string) != "" {
-				vStr, err := strconv.ParseInt(v.(string), 10, 64)
-				if err != nil {
-					return err
-				}
-				period.StartTime = vStr
-			}
-			if v, ok := periodMap["end_time"]; ok && v.(string) != "" {
-				vStr, err := strconv.ParseInt(v.(string), 10, 64)
-				if err != nil {
-					return err
-				}
-				period.EndTime = vStr
-			}
-			filter.Period = &period
-		}
-		if v, ok := filterMap["prefix"]; ok {
-			filter.Prefix = v.(string)
-		}
-	}
-	var optionalFields cos.BucketInventoryOptionalFields
-	if v, ok := d.GetOk("optional_fields"); ok && len(v.([]interface{})) != 0 {
-		optionalFieldsMap := v.([]interface{})[0].(map[string]interface{})
-		if v, ok := optionalFieldsMap["fields"]; ok {
-			optionalFields.BucketInventoryFields = make([]string, 0)
-			for _, field := range v.(*schema.Set).List() {
-				optionalFields.BucketInventoryFields = append(optionalFields.BucketInventoryFields, field.(string))
-			}
-		}
+	opt, err := buildCosBucketInventoryOptions(d)
+	if err != nil {
+		return err
 	}
-
-	var schedule cos.BucketInventorySchedule
-	if v, ok := d.GetOk("schedule"); ok && len(v.([]interface{})) != 0 {
-		scheduleMap := v.([]interface{})[0].(map[string]interface{})
-		if v, ok := scheduleMap["frequency"]; ok {
-			schedule.Frequency = v.(string)
-		}
+	inventories, err := cosBucketInventoryPrefixes(name, d.Get("filter"))
+	if err != nil {
+		return err
 	}
 
-	var destination cos.BucketInventoryDestination
-	if v, ok := d.GetOk("destination"); ok && len(v.([]interface{})) != 0 {
-		destinationMap := v.([]interface{})[0].(map[string]interface{})
-		if v, ok := destinationMap["bucket"]; ok {
-			destination.Bucket = v.(string)
-		}
-		if v, ok := destinationMap["account_id"]; ok {
-			destination.AccountId = v.(string)
-		}
-		if v, ok := destinationMap["prefix"]; ok {
-			destination.Prefix = v.(string)
-		}
-		if v, ok := destinationMap["format"]; ok {
-			destination.Format = v.(string)
-		}
-		if v, ok := destinationMap["encryption"]; ok && len(v.([]interface{})) > 0 {
-			encryptionMap := v.([]interface{})[0].(map[string]interface{})
-			if v, ok := encryptionMap["sse_cos"]; ok {
-				destination.Encryption = &cos.BucketInventoryEncryption{
-					SSECOS: v.(string),
-				}
-
+	// check all the derived ids before putting any of them, so an inventory owned by others is never taken over
+	ids := cosBucketInventoryIds(inventories)
+	for _, id := range ids {
+		if id != name {
+			if err := checkCosBucketInventoryNotExist(ctx, meta, bucket, id); err != nil {
+				return err
 			}
 		}
 	}
 
-	opt := &cos.BucketPutInventoryOptions{
-		ID:                     name,
-		IsEnabled:              isEnabled,
-		IncludedObjectVersions: includedObjectVersions,
-		Filter:                 &filter,
-		OptionalFields:         &optionalFields,
-		Schedule:               &schedule,
-		Destination:            &destination,
-	}
+	// set the id before putting, so the inventories already put are deleted when a later one fails
+	d.SetId(bucket + FILED_SP + name)
 
-	err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
-		req, _ := json.Marshal(opt)
-		resp, e := meta.(*TencentCloudClient).apiV3Conn.UseTencentCosClient(bucket).Bucket.PutInventory(ctx, name, opt)
-		responseBody, _ := json.Marshal(resp.Body)
-		if e != nil {
-			log.Printf("[DEBUG]%s api[PutInventory] success, request body [%s], response body [%s], err: [%s]\n", logId, req, responseBody, e.Error())
-			return retryError(e)
+	for _, id := range ids {
+		if err := putCosBucketInventory(ctx, meta, bucket, id, inventories[id], opt); err != nil {
+			return err
 		}
-		return nil
-	})
-	if err != nil {
-		log.Printf("[CRITAL]%s create cos bucketInventory failed, reason:%+v", logId, err)
-		return err
+		waitCosBucketInventoryReady(ctx, meta, bucket, id)
 	}
-	d.SetId(bucket + FILED_SP + name)
 
 	return resourceTencentCloudCosBucketInventoryRead(d, meta)
 }
@@ -326,18 +297,47 @@ func resourceTencentCloudCosBucketInventoryRead(d *schema.ResourceData, meta int
 	}
 	bucket := idSplit[0]
 	name := idSplit[1]
-	result, _, err := meta.(*TencentCloudClient).apiV3Conn.UseTencentCosClient(bucket).Bucket.GetInventory(ctx, name)
+
+	inventories, err := cosBucketInventoryPrefixes(name, d.Get("filter"))
 	if err != nil {
-		log.Printf("[CRITAL]%s get cos bucketInventory failed, reason:%+v", logId, err)
 		return err
 	}
+	_, single := inventories[name]
+
+	var result *cos.BucketGetInventoryResult
+	prefixes := make([]string, 0, len(inventories))
+	for _, id := range cosBucketInventoryIds(inventories) {
+		inventory, _, err := meta.(*TencentCloudClient).apiV3Conn.UseTencentCosClient(bucket).Bucket.GetInventory(ctx, id)
+		if err != nil {
+			if cos.IsNotFoundError(err) {
+				log.Printf("[WARN]%s cos bucketInventory [%s] of bucket [%s] not found\n", logId, id, bucket)
+				continue
+			}
+			log.Printf("[CRITAL]%s get cos bucketInventory failed, reason:%+v", logId, err)
+			return err
+		}
+		if result == nil {
+			result = inventory
+		}
+		prefixes = append(prefixes, inventories[id])
+	}
+	if result == nil {
+		d.SetId("")
+		return nil
+	}
+
 	_ = d.Set("bucket", bucket)
 	_ = d.Set("name", name)
 	_ = d.Set("is_enabled", result.IsEnabled)
 	_ = d.Set("included_object_versions", result.IncludedObjectVersions)
-	filterMap := make(map[string]interface{})
 	if result.Filter != nil {
+		filterMap := make(map[string]interface{})
 		filterMap["prefix"] = result.Filter.Prefix
+		if !single {
+			filterMap["prefix"] = ""
+			filterMap["prefixes"] = prefixes
+		}
+		filterMap["storage_class"] = result.Filter.StorageClass
 		periodMap := make(map[string]interface{})
 		if result.Filter.Period != nil {
 			if result.Filter.Period.StartTime != 0 {
@@ -348,8 +348,10 @@ func resourceTencentCloudCosBucketInventoryRead(d *schema.ResourceData, meta int
 			}
 			filterMap["period"] = []interface{}{periodMap}
 		}
+		_ = d.Set("filter", []interface{}{filterMap})
+	} else {
+		_ = d.Set("filter", nil)
 	}
-	_ = d.Set("filter", []interface{}{filterMap})
 	optionalFieldsMap := make(map[string]interface{})
 	if result.OptionalFields != nil {
 		fields := make([]string, 0)
@@ -399,35 +401,86 @@ func resourceTencentCloudCosBucketInventoryUpdate(d *schema.ResourceData, meta i
 	if !d.HasChange("is_enabled") && !d.HasChange("included_object_versions") && !d.HasChange("filter") && !d.HasChange("optional_fields") && !d.HasChange("schedule") && !d.HasChange("destination") {
 		return resourceTencentCloudCosBucketInventoryRead(d, meta)
 	}
-	isEnabled := d.Get("is_enabled").(string)
-	includedObjectVersions := d.Get("included_object_versions").(string)
 
-	var filter cos.BucketInventoryFilter
-	if v, ok := d.GetOk("filter"); ok && len(v.([]interface{})) != 0 {
-		var period cos.BucketInventoryFilterPeriod
-		filterMap := v.([]interface{})[0].(map[string]interface{})
-		if v, ok := filterMap["period"]; ok && len(v.([]interface{})) > 0 {
-			periodMap := v.([]interface{})[0].(map[string]interface{})
-			if v, ok := periodMap["start_time"]; ok && v.(string) != "" {
-				vStr, err := strconv.ParseInt(v.(string), 10, 64)
-				if err != nil {
-					return err
-				}
-				period.StartTime = vStr
+	opt, err := buildCosBucketInventoryOptions(d)
+	if err != nil {
+		return err
+	}
+	oldFilter, newFilter := d.GetChange("filter")
+	oldInventories, err := cosBucketInventoryPrefixes(name, oldFilter)
+	if err != nil {
+		return err
+	}
+	newInventories, err := cosBucketInventoryPrefixes(name, newFilter)
+	if err != nil {
+		return err
+	}
+
+	// check all the added ids before putting any of them, so an inventory owned by others is never taken over
+	newIds := cosBucketInventoryIds(newInventories)
+	for _, id := range newIds {
+		if _, ok := oldInventories[id]; !ok && id != name {
+			if err := checkCosBucketInventoryNotExist(ctx, meta, bucket, id); err != nil {
+				return err
 			}
-			if v, ok := periodMap["end_time"]; ok && v.(string) != "" {
-				vStr, err := strconv.ParseInt(v.(string), 10, 64)
-				if err != nil {
-					return err
-				}
-				period.EndTime = vStr
+		}
+	}
+
+	// put the new inventories before deleting the stale ones, so the objects are never left uncovered
+	for _, id := range newIds {
+		if err := putCosBucketInventory(ctx, meta, bucket, id, newInventories[id], opt); err != nil {
+			return err
+		}
+		waitCosBucketInventoryReady(ctx, meta, bucket, id)
+	}
+	for _, id := range cosBucketInventoryIds(oldInventories) {
+		if _, ok := newInventories[id]; !ok {
+			if err := deleteCosBucketInventory(ctx, meta, bucket, id); err != nil {
+				return err
 			}
-			filter.Period = &period
 		}
-		if v, ok := filterMap["prefix"]; ok {
-			filter.Prefix = v.(string)
+	}
+
+	return resourceTencentCloudCosBucketInventoryRead(d, meta)
+}
+
+func resourceTencentCloudCosBucketInventoryDelete(d *schema.ResourceData, meta interface{}) error {
+	defer logElapsed("resource.tencentcloud_cos_bucket_inventory.delete")()
+	defer inconsistentCheck(d, meta)()
+
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+	idSplit := strings.Split(d.Id(), FILED_SP)
+	if len(idSplit) != 2 {
+		return fmt.Errorf("id is broken,%s", d.Id())
+	}
+	bucket := idSplit[0]
+	name := idSplit[1]
+
+	inventories, err := cosBucketInventoryPrefixes(name, d.Get("filter"))
+	if err != nil {
+		return err
+	}
+	for _, id := range cosBucketInventoryIds(inventories) {
+		if err := deleteCosBucketInventory(ctx, meta, bucket, id); err != nil {
+			return err
 		}
 	}
+
+	return nil
+}
+
+// buildCosBucketInventoryOptions builds the inventory options shared by all the inventories of the resource,
+// the id and prefix are filled in by putCosBucketInventory.
+func buildCosBucketInventoryOptions(d *schema.ResourceData) (*cos.BucketPutInventoryOptions, error) {
+	isEnabled := d.Get("is_enabled").(string)
+	includedObjectVersions := d.Get("included_object_versions").(string)
+
+	filter, err := buildCosBucketInventoryFilter(d)
+	if err != nil {
+		return nil, err
+	}
 	var optionalFields cos.BucketInventoryOptionalFields
 	if v, ok := d.GetOk("optional_fields"); ok && len(v.([]interface{})) != 0 {
 		optionalFieldsMap := v.([]interface{})[0].(map[string]interface{})
@@ -473,19 +526,125 @@ func resourceTencentCloudCosBucketInventoryUpdate(d *schema.ResourceData, meta i
 		}
 	}
 
-	opt := &cos.BucketPutInventoryOptions{
-		ID:                     name,
+	return &cos.BucketPutInventoryOptions{
 		IsEnabled:              isEnabled,
 		IncludedObjectVersions: includedObjectVersions,
-		Filter:                 &filter,
+		Filter:                 filter,
 		OptionalFields:         &optionalFields,
 		Schedule:               &schedule,
 		Destination:            &destination,
+	}, nil
+}
+
+// buildCosBucketInventoryFilter returns nil when no filter is configured, so the whole bucket is inventoried.
+func buildCosBucketInventoryFilter(d *schema.ResourceData) (*cos.BucketInventoryFilter, error) {
+	v, ok := d.GetOk("filter")
+	if !ok || len(v.([]interface{})) == 0 || v.([]interface{})[0] == nil {
+		return nil, nil
+	}
+
+	var filter cos.BucketInventoryFilter
+	filterMap := v.([]interface{})[0].(map[string]interface{})
+	if v, ok := filterMap["period"]; ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		var period cos.BucketInventoryFilterPeriod
+		periodMap := v.([]interface{})[0].(map[string]interface{})
+		if v, ok := periodMap["start_time"]; ok && v.(string) != "" {
+			vStr, err := strconv.ParseInt(v.(string), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			period.StartTime = vStr
+		}
+		if v, ok := periodMap["end_time"]; ok && v.(string) != "" {
+			vStr, err := strconv.ParseInt(v.(string), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			period.EndTime = vStr
+		}
+		filter.Period = &period
+	}
+	if v, ok := filterMap["prefix"]; ok {
+		filter.Prefix = v.(string)
+	}
+	if v, ok := filterMap["storage_class"]; ok {
+		filter.StorageClass = v.(string)
+	}
+	return &filter, nil
+}
+
+// cosBucketInventoryPrefixes returns the inventories managed by the resource, keyed by inventory id with their prefixes.
+// With `prefixes` an inventory is derived for each prefix, otherwise only the inventory `name` is managed.
+func cosBucketInventoryPrefixes(name string, filter interface{}) (map[string]string, error) {
+	inventories := make(map[string]string)
+	filters, _ := filter.([]interface{})
+	if len(filters) == 0 || filters[0] == nil {
+		inventories[name] = ""
+		return inventories, nil
+	}
+
+	filterMap := filters[0].(map[string]interface{})
+	var prefixes []interface{}
+	if v, ok := filterMap["prefixes"].(*schema.Set); ok {
+		prefixes = v.List()
+	}
+	if len(prefixes) == 0 {
+		prefix, _ := filterMap["prefix"].(string)
+		inventories[name] = prefix
+		return inventories, nil
+	}
+
+	for _, v := range prefixes {
+		prefix := v.(string)
+		id := cosBucketInventoryDerivedId(name, prefix)
+		if other, ok := inventories[id]; ok {
+			return nil, fmt.Errorf("prefixes `%s` and `%s` derive the same inventory id `%s`, please rename one of them", other, prefix, id)
+		}
+		inventories[id] = prefix
+	}
+	return inventories, nil
+}
+
+// cosBucketInventoryDerivedId derives the inventory id of a prefix, it stays the same as long as the prefix does.
+func cosBucketInventoryDerivedId(name, prefix string) string {
+	return fmt.Sprintf("%s-%08x", name, crc32.ChecksumIEEE([]byte(prefix)))
+}
+
+func cosBucketInventoryIds(inventories map[string]string) []string {
+	ids := make([]string, 0, len(inventories))
+	for id := range inventories {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// checkCosBucketInventoryNotExist refuses to take over an inventory not created by the resource.
+func checkCosBucketInventoryNotExist(ctx context.Context, meta interface{}, bucket, id string) error {
+	_, _, err := meta.(*TencentCloudClient).apiV3Conn.UseTencentCosClient(bucket).Bucket.GetInventory(ctx, id)
+	if err == nil {
+		return fmt.Errorf("inventory `%s` already exists in bucket %s, it can not be derived from `prefixes`", id, bucket)
+	}
+	if cos.IsNotFoundError(err) {
+		return nil
+	}
+	return err
+}
+
+func putCosBucketInventory(ctx context.Context, meta interface{}, bucket, id, prefix string, template *cos.BucketPutInventoryOptions) error {
+	logId := getLogId(ctx)
+
+	opt := *template
+	opt.ID = id
+	if opt.Filter != nil {
+		filter := *opt.Filter
+		filter.Prefix = prefix
+		opt.Filter = &filter
 	}
 
 	err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
 		req, _ := json.Marshal(opt)
-		resp, e := meta.(*TencentCloudClient).apiV3Conn.UseTencentCosClient(bucket).Bucket.PutInventory(ctx, name, opt)
+		resp, e := meta.(*TencentCloudClient).apiV3Conn.UseTencentCosClient(bucket).Bucket.PutInventory(ctx, id, &opt)
 		responseBody, _ := json.Marshal(resp.Body)
 		if e != nil {
 			log.Printf("[DEBUG]%s api[PutInventory] success, request body [%s], response body [%s], err: [%s]\n", logId, req, responseBody, e.Error())
@@ -494,30 +653,41 @@ func resourceTencentCloudCosBucketInventoryUpdate(d *schema.ResourceData, meta i
 		return nil
 	})
 	if err != nil {
-		log.Printf("[CRITAL]%s create cos bucketInventory failed, reason:%+v", logId, err)
+		log.Printf("[CRITAL]%s put cos bucketInventory [%s] failed, reason:%+v", logId, id, err)
 		return err
 	}
-
-	return resourceTencentCloudCosBucketInventoryRead(d, meta)
+	return nil
 }
 
-func resourceTencentCloudCosBucketInventoryDelete(d *schema.ResourceData, meta interface{}) error {
-	defer logElapsed("resource.tencentcloud_cos_bucket_inventory.delete")()
-	defer inconsistentCheck(d, meta)()
-
-	logId := getLogId(contextNil)
-	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+// waitCosBucketInventoryReady polls until the inventory just put can be got, so the following Read does not
+// drop it on a 404. It gives up with a warning after COS_INVENTORY_READY_TIMEOUT, the Read then decides.
+func waitCosBucketInventoryReady(ctx context.Context, meta interface{}, bucket, id string) {
+	logId := getLogId(ctx)
 
-	idSplit := strings.Split(d.Id(), FILED_SP)
-	if len(idSplit) != 2 {
-		return fmt.Errorf("id is broken,%s", d.Id())
+	err := resource.Retry(COS_INVENTORY_READY_TIMEOUT, func() *resource.RetryError {
+		_, _, e := meta.(*TencentCloudClient).apiV3Conn.UseTencentCosClient(bucket).Bucket.GetInventory(ctx, id)
+		if e != nil {
+			if cos.IsNotFoundError(e) {
+				return resource.RetryableError(e)
+			}
+			return resource.NonRetryableError(e)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("[WARN]%s cos bucketInventory [%s] is not retrievable yet, reason:%+v", logId, id, err)
 	}
-	bucket := idSplit[0]
-	name := idSplit[1]
+}
+
+func deleteCosBucketInventory(ctx context.Context, meta interface{}, bucket, id string) error {
+	logId := getLogId(ctx)
 
 	err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
-		resp, e := meta.(*TencentCloudClient).apiV3Conn.UseTencentCosClient(bucket).Bucket.DeleteInventory(ctx, name)
+		resp, e := meta.(*TencentCloudClient).apiV3Conn.UseTencentCosClient(bucket).Bucket.DeleteInventory(ctx, id)
 		if e != nil {
+			if cos.IsNotFoundError(e) {
+				return nil
+			}
 			log.Printf("[CRITAL][retry]%s api[%s] fail, resp body [%s], reason[%s]\n",
 				logId, "DeleteInventory ", resp.Body, e.Error())
 			return retryError(e)
@@ -525,9 +695,25 @@ func resourceTencentCloudCosBucketInventoryDelete(d *schema.ResourceData, meta i
 		return nil
 	})
 	if err != nil {
-		log.Printf("[CRITAL]%s delete cos bucketInventory failed, reason:%+v", logId, err)
+		log.Printf("[CRITAL]%s delete cos bucketInventory [%s] failed, reason:%+v", logId, id, err)
 		return err
 	}
-
 	return nil
 }
+
+func resourceTencentCloudCosBucketInventoryScheduleDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("schedule.0.frequency") || !d.NewValueKnown("destination.0.format") {
+		return nil
+	}
+	return checkCosBucketInventorySchedule(d.Get("schedule.0.frequency").(string), d.Get("destination.0.format").(string))
+}
+
+// checkCosBucketInventorySchedule returns an error if the frequency is not supported by a known format.
+func checkCosBucketInventorySchedule(frequency, format string) error {
+	frequencies, ok := COS_INVENTORY_FORMAT_FREQUENCIES[format]
+	if !ok || frequency == "" || IsContains(frequencies, frequency) {
+		return nil
+	}
+	return fmt.Errorf("schedule.0.frequency: `%s` is not supported by destination format `%s`, valid values: %s",
+		frequency, format, strings.Join(frequencies, ", "))
+}
@@ -1,9 +1,11 @@
 package tencentcloud
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestAccTencentCloudCosBucketInventoryResource(t *testing.T) {
@@ -24,10 +26,97 @@ func TestAccTencentCloudCosBucketInventoryResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				Config: testAccCosBucketInventoryPrefix("logs/"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_inventory.bucket_inventory", "id", "keep-test-1308919341#test123"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_inventory.bucket_inventory", "filter.0.prefix", "logs/"),
+				),
+			},
+			{
+				Config: testAccCosBucketInventoryPrefix("images/"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_inventory.bucket_inventory", "id", "keep-test-1308919341#test123"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_inventory.bucket_inventory", "filter.0.prefix", "images/"),
+				),
+			},
+			{
+				Config: testAccCosBucketInventoryStorageClass,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_inventory.bucket_inventory", "id", "keep-test-1308919341#test123"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_inventory.bucket_inventory", "filter.0.prefix", "images/"),
+					resource.TestCheckResourceAttr("tencentcloud_cos_bucket_inventory.bucket_inventory", "filter.0.storage_class", "Standard,StandardIA"),
+				),
+			},
 		},
 	})
 }
 
+func TestCosBucketInventoryPrefixes(t *testing.T) {
+	res := resourceTencentCloudCosBucketInventory()
+	filterOf := func(raw map[string]interface{}) interface{} {
+		d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{"filter": []interface{}{raw}})
+		return d.Get("filter")
+	}
+
+	inventories, err := cosBucketInventoryPrefixes("test123", nil)
+	if err != nil || len(inventories) != 1 || inventories["test123"] != "" {
+		t.Fatalf("expected the inventory itself without filter, got %v, %v", inventories, err)
+	}
+
+	inventories, err = cosBucketInventoryPrefixes("test123", filterOf(map[string]interface{}{"prefix": "logs/"}))
+	if err != nil || len(inventories) != 1 || inventories["test123"] != "logs/" {
+		t.Fatalf("expected the inventory itself with prefix, got %v, %v", inventories, err)
+	}
+
+	inventories, err = cosBucketInventoryPrefixes("test123", filterOf(map[string]interface{}{"prefixes": []interface{}{"logs/", "images/"}}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(inventories) != 2 {
+		t.Fatalf("expected 2 inventories, got %v", inventories)
+	}
+	for _, prefix := range []string{"logs/", "images/"} {
+		id := cosBucketInventoryDerivedId("test123", prefix)
+		if inventories[id] != prefix {
+			t.Fatalf("expected inventory %s for prefix %s, got %v", id, prefix, inventories)
+		}
+	}
+	if _, ok := inventories["test123"]; ok {
+		t.Fatalf("expected the inventory itself not to be managed with prefixes, got %v", inventories)
+	}
+
+	if cosBucketInventoryDerivedId("test123", "logs/") != cosBucketInventoryDerivedId("test123", "logs/") {
+		t.Fatalf("expected the derived id to be stable")
+	}
+	if cosBucketInventoryDerivedId("test123", "logs/") == cosBucketInventoryDerivedId("test124", "logs/") {
+		t.Fatalf("expected the derived id to depend on the name")
+	}
+}
+
+func TestCheckCosBucketInventorySchedule(t *testing.T) {
+	cases := []struct {
+		frequency string
+		format    string
+		valid     bool
+	}{
+		{"Daily", "CSV", true},
+		{"Weekly", "CSV", true},
+		{"Monthly", "CSV", false},
+		{"Monthly", "ORC", true},
+		{"", "CSV", true},
+	}
+	for _, c := range cases {
+		err := checkCosBucketInventorySchedule(c.frequency, c.format)
+		if c.valid && err != nil {
+			t.Errorf("%s/%s: expected valid, got %v", c.frequency, c.format, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("%s/%s: expected an error", c.frequency, c.format)
+		}
+	}
+}
+
 const testAccCosBucketInventory = `
 resource "tencentcloud_cos_bucket_inventory" "bucket_inventory" {
     name = "test123"
@@ -54,3 +143,62 @@ resource "tencentcloud_cos_bucket_inventory" "bucket_inventory" {
     }
 }
 `
+
+func testAccCosBucketInventoryPrefix(prefix string) string {
+	return fmt.Sprintf(`
+resource "tencentcloud_cos_bucket_inventory" "bucket_inventory" {
+    name = "test123"
+    bucket = "keep-test-1308919341"
+    is_enabled = "true"
+    included_object_versions = "Current"
+    optional_fields {
+        fields = ["Size", "ETag"]
+    }
+    filter {
+        prefix = "%s"
+        period {
+            start_time = "1687276800"
+        }
+    }
+    schedule {
+        frequency = "Weekly"
+    }
+    destination {
+        bucket = "qcs::cos:ap-guangzhou::keep-test-1308919341"
+        account_id = ""
+        format = "CSV"
+        prefix = "cos_bucket_inventory"
+
+    }
+}
+`, prefix)
+}
+
+const testAccCosBucketInventoryStorageClass = `
+resource "tencentcloud_cos_bucket_inventory" "bucket_inventory" {
+    name = "test123"
+    bucket = "keep-test-1308919341"
+    is_enabled = "true"
+    included_object_versions = "Current"
+    optional_fields {
+        fields = ["Size", "ETag"]
+    }
+    filter {
+        prefix = "images/"
+        storage_class = "Standard,StandardIA"
+        period {
+            start_time = "1687276800"
+        }
+    }
+    schedule {
+        frequency = "Weekly"
+    }
+    destination {
+        bucket = "qcs::cos:ap-guangzhou::keep-test-1308919341"
+        account_id = ""
+        format = "CSV"
+        prefix = "cos_bucket_inventory"
+
+    }
+}
+`
@@ -254,6 +254,12 @@ func resourceTencentCloudDnatRead(d *schema.ResourceData, meta interface{}) erro
 		return nil
 	})
 	if err != nil {
+		// the rules are gone with the NAT gateway
+		if isExpectError(err, []string{"ResourceNotFound"}) {
+			log.Printf("[WARN]%s nat gateway of DNAT [%s] not found, remove it from state\n", logId, d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[CRITAL]%s read DNAT failed, reason:%s\n", logId, err.Error())
 		return err
 	}
@@ -268,9 +274,13 @@ func resourceTencentCloudDnatRead(d *schema.ResourceData, meta interface{}) erro
 	_ = d.Set("nat_id", dnat.NatGatewayId)
 	_ = d.Set("protocol", dnat.IpProtocol)
 	_ = d.Set("elastic_ip", dnat.PublicIpAddress)
-	_ = d.Set("elastic_port", strconv.Itoa(int(*dnat.PublicPort)))
+	if dnat.PublicPort != nil {
+		_ = d.Set("elastic_port", strconv.Itoa(int(*dnat.PublicPort)))
+	}
 	_ = d.Set("private_ip", dnat.PrivateIpAddress)
-	_ = d.Set("private_port", strconv.Itoa(int(*dnat.PrivatePort)))
+	if dnat.PrivatePort != nil {
+		_ = d.Set("private_port", strconv.Itoa(int(*dnat.PrivatePort)))
+	}
 	_ = d.Set("description", dnat.Description)
 	return nil
 }
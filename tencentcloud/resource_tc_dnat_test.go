@@ -32,6 +32,11 @@ func TestAccTencentCloudNatGatewayTransRule_basic(t *testing.T) {
 					testAccCheckDnatExists("tencentcloud_dnat.dev_dnat", &dnatId),
 				),
 			},
+			{
+				ResourceName:      "tencentcloud_dnat.dev_dnat",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
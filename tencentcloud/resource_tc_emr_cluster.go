@@ -95,7 +95,14 @@ import (
 	"context"
 	innerErr "errors"
 	"fmt"
-
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
@@ -106,16 +113,34 @@ import (
 
 func resourceTencentCloudEmrCluster() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTencentCloudEmrClusterCreate,
-		Read:   resourceTencentCloudEmrClusterRead,
-		Delete: resourceTencentCloudEmrClusterDelete,
-		Update: resourceTencentCloudEmrClusterUpdate,
+		CreateContext: resourceTencentCloudEmrClusterCreate,
+		ReadContext:   resourceTencentCloudEmrClusterRead,
+		DeleteContext: resourceTencentCloudEmrClusterDelete,
+		UpdateContext: resourceTencentCloudEmrClusterUpdate,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * readRetryTimeout),
+			Update: schema.DefaultTimeout(10 * readRetryTimeout),
+			Delete: schema.DefaultTimeout(10 * readRetryTimeout),
+		},
+
+		CustomizeDiff: customdiff.All(
+			resourceTencentCloudEmrClusterVpcSettingsDiff,
+			resourceTencentCloudEmrClusterSoftwaresDiff,
+			resourceTencentCloudEmrClusterProductSoftwaresDiff,
+			resourceTencentCloudEmrClusterQuotaDiff,
+			resourceTencentCloudEmrClusterDiskEncryptDiff,
+			resourceTencentCloudEmrClusterResourceSpecDiff,
+			resourceTencentCloudEmrClusterAutoRenewDiff,
+		),
+
 		Schema: map[string]*schema.Schema{
 			"display_strategy": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Display strategy of EMR instance.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      DisplayStrategyIsclusterList,
+				ValidateFunc: validateAllowedStringValue(EMR_DISPLAY_STRATEGIES),
+				Description:  "Display strategy of EMR instance, valid values are `clusterList` and `monitorManage`. Default is `clusterList`. It can not be read back from the API, `clusterList` is assumed when it is absent from the state.",
 			},
 			"product_id": {
 				Type:     schema.TypeInt,
@@ -139,14 +164,26 @@ func resourceTencentCloudEmrCluster() *schema.Resource {
 				Type:        schema.TypeMap,
 				Required:    true,
 				ForceNew:    true,
-				Description: "The private net config of EMR instance.",
+				Description: "The private net config of EMR instance. Only `vpc_id` and `subnet_id` are allowed, and both are required.",
 			},
 			"softwares": {
 				Type:        schema.TypeList,
 				Required:    true,
 				ForceNew:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
-				Description: "The softwares of a EMR instance.",
+				Description: "The softwares of a EMR instance. Each of them must be a component of the product family of `product_id`, e.g. only `kafka` and `zookeeper` for KAFKA products.",
+			},
+			"validate_softwares": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to check at plan time that `softwares` can be deployed in `placement.zone`. The check is skipped when the EMR API is unavailable. Default is `false`.",
+			},
+			"validate_quota": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to check at plan time that the CVM instance quota of `placement.zone` can hold the requested nodes. The check is skipped when the quota can not be queried. Default is `false`.",
 			},
 			"resource_spec": {
 				Type:     schema.TypeList,
@@ -160,28 +197,27 @@ func resourceTencentCloudEmrCluster() *schema.Resource {
 						"master_count": {
 							Type:        schema.TypeInt,
 							Optional:    true,
-							Description: "The number of master node.",
+							Description: "The number of master node. It can not be decreased.",
 						},
 						"core_count": {
 							Type:        schema.TypeInt,
 							Optional:    true,
-							Description: "The number of core node.",
+							Description: "The number of core node. It can not be decreased, only task nodes support scaling in.",
 						},
 						"task_count": {
 							Type:        schema.TypeInt,
 							Optional:    true,
-							Description: "The number of core node.",
+							Description: "The number of task node. Decreasing it terminates the extra task nodes, setting it to `0` removes all of them.",
 						},
 						"common_resource_spec": buildResourceSpecSchema(),
 						"common_count": {
 							Type:        schema.TypeInt,
 							Optional:    true,
-							ForceNew:    true,
-							Description: "The number of common node.",
+							Description: "The number of common node. Changing it replaces the cluster.",
 						},
 					},
 				},
-				Description: "Resource specification of EMR instance.",
+				Description: "Resource specification of EMR instance. Changing the node specs or `common_count` replaces the cluster, while `master_count`, `core_count` and `task_count` are changed online.",
 			},
 			"support_ha": {
 				Type:         schema.TypeInt,
@@ -209,6 +245,12 @@ func resourceTencentCloudEmrCluster() *schema.Resource {
 				ForceNew:    true,
 				Description: "The location of the instance.",
 			},
+			"auto_renew": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateIntegerInRange(0, 1),
+				Description:  "Whether to renew the instance automatically when it expires, only works when `pay_mode` is 1. 0 means no auto renew, 1 means auto renew. EMR does not support modifying it after creation.",
+			},
 			"time_span": {
 				Type:        schema.TypeInt,
 				Required:    true,
@@ -220,10 +262,12 @@ func resourceTencentCloudEmrCluster() *schema.Resource {
 				Description: "The unit of time in which the instance was purchased. When PayMode is 0, TimeUnit can only take values of s(second). When PayMode is 1, TimeUnit can only take the value m(month).",
 			},
 			"login_settings": {
-				Type:        schema.TypeMap,
-				Required:    true,
+				Type:     schema.TypeMap,
+				Required: true,
+				// EMR provides no API to reset the login password, so changing it has to rebuild the cluster
 				ForceNew:    true,
-				Description: "Instance login settings.",
+				Sensitive:   true,
+				Description: "Instance login settings. Changing the password will rebuild the cluster, since EMR does not support resetting it in place.",
 			},
 			"extend_fs_field": {
 				Type:        schema.TypeString,
@@ -252,6 +296,85 @@ func resourceTencentCloudEmrCluster() *schema.Resource {
 				ForceNew:    true,
 				Description: "The ID of the security group to which the instance belongs, in the form of sg-xxxxxxxx.",
 			},
+			"disk_encrypt": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Whether to encrypt the cloud disks of the nodes. Local disks can not be encrypted, so every `disk_type` in `resource_spec` must be a cloud disk type when it is enabled.",
+			},
+			"meta_db_info": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "Hive metadb settings of the instance. If not set, a dedicated metadb is created with the cluster.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"meta_type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateAllowedStringValue(EMR_META_TYPES),
+							Description: "Hive metadb type. Valid values: `EMR_NEW_META` (create a dedicated metadb with the cluster), " +
+								"`EMR_EXIT_META` (share the metadb of an existing EMR cluster), `USER_CUSTOM_META` (use a self-built metadb).",
+						},
+						"unify_meta_instance_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "ID of the existing EMR metadb instance to share. Required when `meta_type` is `EMR_EXIT_META`.",
+						},
+						"meta_data_jdbc_url": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "JDBC url of the self-built metadb, including the database name, such as `jdbc:mysql://10.0.0.1:3306/hivemetastore`. Required when `meta_type` is `USER_CUSTOM_META`.",
+						},
+						"meta_data_user": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "User name of the self-built metadb. Required when `meta_type` is `USER_CUSTOM_META`.",
+						},
+						"meta_data_pass": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Sensitive:   true,
+							Description: "Password of the self-built metadb. Required when `meta_type` is `USER_CUSTOM_META`.",
+						},
+					},
+				},
+			},
+			"bootstrap_actions": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Bootstrap actions, scripts which are run on every node when the cluster launches.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "COS path of the script, such as `https://bucket-1250000000.cos.ap-guangzhou.myqcloud.com/init.sh`.",
+						},
+						"args": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							ForceNew:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Arguments of the script.",
+						},
+						"run_order": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Run order of the script, scripts with smaller orders run first.",
+						},
+					},
+				},
+			},
 			"tags": {
 				Type:        schema.TypeMap,
 				Optional:    true,
@@ -262,10 +385,10 @@ func resourceTencentCloudEmrCluster() *schema.Resource {
 	}
 }
 
-func resourceTencentCloudEmrClusterUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceTencentCloudEmrClusterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	defer logElapsed("resource.tencentcloud_emr_cluster.update")()
 	logId := getLogId(contextNil)
-	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+	ctx = context.WithValue(ctx, logIdKey, logId)
 	emrService := EMRService{
 		client: meta.(*TencentCloudClient).apiV3Conn,
 	}
@@ -274,7 +397,13 @@ func resourceTencentCloudEmrClusterUpdate(d *schema.ResourceData, meta interface
 	timeSpan, hasTimeSpan := d.GetOkExists("time_span")
 	payMode, hasPayMode := d.GetOkExists("pay_mode")
 	if !hasTimeUnit || !hasTimeSpan || !hasPayMode {
-		return innerErr.New("Time_unit, time_span or pay_mode must be set.")
+		return diag.FromErr(innerErr.New("Time_unit, time_span or pay_mode must be set."))
+	}
+	// another apply may still be scaling the cluster, wait for it so the following calls do not fail on a transient state
+	err := helper.WaitForState(ctx, emrService.EmrClusterStateFunc(ctx, instanceId, DisplayStrategyIsclusterList),
+		[]string{helper.Int64ToStr(EmrInternetStatusCreated)}, []string{EmrClusterStateNotFound}, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return diag.Errorf("EMR cluster %s is not ready for modification, another operation may still be in progress: %s", instanceId, err.Error())
 	}
 	if d.HasChange("tags") {
 		tcClient := meta.(*TencentCloudClient).apiV3Conn
@@ -283,7 +412,7 @@ func resourceTencentCloudEmrClusterUpdate(d *schema.ResourceData, meta interface
 		replaceTags, deleteTags := diffTags(oldTags.(map[string]interface{}), newTags.(map[string]interface{}))
 		resourceName := BuildTagResourceName("emr", "emr-instance", tcClient.Region, instanceId)
 		if err := tagService.ModifyTags(ctx, resourceName, replaceTags, deleteTags); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -298,65 +427,102 @@ func resourceTencentCloudEmrClusterUpdate(d *schema.ResourceData, meta interface
 	resourceSpec := tmpResourceSpec[0].(map[string]interface{})
 
 	if d.HasChange("resource_spec.0.master_count") {
-		request.MasterCount = common.Uint64Ptr((uint64)(resourceSpec["master_count"].(int)))
+		o, _ := d.GetChange("resource_spec.0.master_count")
+		masterCount := resourceSpec["master_count"].(int)
+		if masterCount < o.(int) {
+			return diag.Errorf("master_count can not be decreased, EMR does not support scaling in master nodes")
+		}
+		request.MasterCount = common.Uint64Ptr((uint64)(masterCount))
 		hasChange = true
 	}
+	removeTaskCount := 0
 	if d.HasChange("resource_spec.0.task_count") {
-		request.TaskCount = common.Uint64Ptr((uint64)(resourceSpec["task_count"].(int)))
-		hasChange = true
+		o, _ := d.GetChange("resource_spec.0.task_count")
+		taskCount := resourceSpec["task_count"].(int)
+		if taskCount < o.(int) {
+			removeTaskCount = o.(int) - taskCount
+		} else {
+			request.TaskCount = common.Uint64Ptr((uint64)(taskCount))
+			hasChange = true
+		}
 	}
 	if d.HasChange("resource_spec.0.core_count") {
-		request.CoreCount = common.Uint64Ptr((uint64)(resourceSpec["core_count"].(int)))
+		o, _ := d.GetChange("resource_spec.0.core_count")
+		coreCount := resourceSpec["core_count"].(int)
+		if coreCount < o.(int) {
+			return diag.Errorf("core_count can not be decreased, EMR only supports scaling in task nodes")
+		}
+		request.CoreCount = common.Uint64Ptr((uint64)(coreCount))
 		hasChange = true
 	}
 	if d.HasChange("extend_fs_field") {
-		return innerErr.New("extend_fs_field not support update.")
+		return diag.FromErr(innerErr.New("extend_fs_field not support update."))
+	}
+	if removeTaskCount > 0 {
+		if err := resourceTencentCloudEmrClusterRemoveTasks(ctx, &emrService, instanceId, removeTaskCount, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 	if !hasChange {
 		return nil
 	}
-	_, err := emrService.UpdateInstance(ctx, request)
+	_, err = emrService.UpdateInstance(ctx, request)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = helper.WaitForState(ctx, emrService.EmrClusterStateFunc(ctx, instanceId, DisplayStrategyIsclusterList),
+		[]string{helper.Int64ToStr(EmrInternetStatusCreated), EmrClusterStateNotFound}, nil, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// resourceTencentCloudEmrClusterRemoveTasks terminates `count` task nodes of the cluster,
+// all the task nodes are terminated when the cluster has no more than `count` of them.
+func resourceTencentCloudEmrClusterRemoveTasks(ctx context.Context, emrService *EMRService, instanceId string, count int, timeout time.Duration) error {
+	resourceIds, err := emrService.DescribeTaskNodeResourceIds(ctx, instanceId)
 	if err != nil {
 		return err
 	}
-	err = resource.Retry(10*readRetryTimeout, func() *resource.RetryError {
-		clusters, err := emrService.DescribeInstancesById(ctx, instanceId, DisplayStrategyIsclusterList)
+	if len(resourceIds) == 0 {
+		return nil
+	}
+	if count < len(resourceIds) {
+		resourceIds = resourceIds[len(resourceIds)-count:]
+	}
 
-		if e, ok := err.(*errors.TencentCloudSDKError); ok {
-			if e.GetCode() == "InternalError.ClusterNotFound" {
-				return nil
-			}
+	err = resource.RetryContext(ctx, writeRetryTimeout, func() *resource.RetryError {
+		if err := ctx.Err(); err != nil {
+			return resource.NonRetryableError(err)
 		}
-
-		if len(clusters) > 0 {
-			status := *(clusters[0].Status)
-			if status != EmrInternetStatusCreated {
-				return resource.RetryableError(
-					fmt.Errorf("%v create cluster endpoint  status still is %v", instanceId, status))
+		e := emrService.TerminateTasks(ctx, instanceId, resourceIds)
+		if e != nil {
+			if isExpectError(e, []string{"UnsupportedOperation", "InvalidParameter.InvalidResourceIds"}) {
+				return resource.NonRetryableError(fmt.Errorf("EMR cluster %s can not remove %d task nodes, reason: %s", instanceId, len(resourceIds), e.Error()))
 			}
-		}
-
-		if err != nil {
-			return resource.RetryableError(err)
+			return retryError(e, InternalError, "ResourceInUse.InstanceInProcess")
 		}
 		return nil
 	})
 	if err != nil {
 		return err
 	}
-	return nil
+
+	return helper.WaitForState(ctx, emrService.EmrClusterStateFunc(ctx, instanceId, DisplayStrategyIsclusterList),
+		[]string{helper.Int64ToStr(EmrInternetStatusCreated), EmrClusterStateNotFound}, nil, timeout)
 }
 
-func resourceTencentCloudEmrClusterCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceTencentCloudEmrClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	defer logElapsed("resource.tencentcloud_emr_cluster.create")()
 	logId := getLogId(contextNil)
-	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+	ctx = context.WithValue(ctx, logIdKey, logId)
 	emrService := EMRService{
 		client: meta.(*TencentCloudClient).apiV3Conn,
 	}
 	instanceId, err := emrService.CreateInstance(ctx, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(instanceId)
 	_ = d.Set("instance_id", instanceId)
@@ -364,63 +530,46 @@ func resourceTencentCloudEmrClusterCreate(d *schema.ResourceData, meta interface
 	if v, ok := d.GetOk("display_strategy"); ok {
 		displayStrategy = v.(string)
 	}
-	err = resource.Retry(10*readRetryTimeout, func() *resource.RetryError {
-		clusters, err := emrService.DescribeInstancesById(ctx, instanceId, displayStrategy)
-
-		if e, ok := err.(*errors.TencentCloudSDKError); ok {
-			if e.GetCode() == "InternalError.ClusterNotFound" {
-				return nil
-			}
-		}
-
-		if len(clusters) > 0 {
-			status := *(clusters[0].Status)
-			if status != EmrInternetStatusCreated {
-				return resource.RetryableError(
-					fmt.Errorf("%v create cluster endpoint  status still is %v", instanceId, status))
-			}
-		}
-
-		if err != nil {
-			return resource.RetryableError(err)
-		}
-		return nil
-	})
+	err = helper.WaitForState(ctx, emrService.EmrClusterStateFunc(ctx, instanceId, displayStrategy),
+		[]string{helper.Int64ToStr(EmrInternetStatusCreated), EmrClusterStateNotFound}, nil, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if tags := helper.GetTags(d, "tags"); len(tags) > 0 {
 		tagService := TagService{client: meta.(*TencentCloudClient).apiV3Conn}
 		region := meta.(*TencentCloudClient).apiV3Conn.Region
-		resourceName := fmt.Sprintf("qcs::emr:%s:uin/:emr-instance/%s", region, d.Id())
+		resourceName := BuildTagResourceName("emr", "emr-instance", region, d.Id())
 		if err := tagService.ModifyTags(ctx, resourceName, tags, nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	return nil
 }
 
-func resourceTencentCloudEmrClusterDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceTencentCloudEmrClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	defer logElapsed("resource.tencentcloud_emr_cluster.delete")()
 	logId := getLogId(contextNil)
-	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+	ctx = context.WithValue(ctx, logIdKey, logId)
 	emrService := EMRService{
 		client: meta.(*TencentCloudClient).apiV3Conn,
 	}
 	instanceId := d.Id()
 	clusters, err := emrService.DescribeInstancesById(ctx, instanceId, DisplayStrategyIsclusterList)
 	if len(clusters) == 0 {
-		return innerErr.New("Not find clusters.")
+		return diag.FromErr(innerErr.New("Not find clusters."))
 	}
 	metaDB := clusters[0].MetaDb
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = emrService.DeleteInstance(ctx, d); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	err = resource.Retry(10*readRetryTimeout, func() *resource.RetryError {
+	err = resource.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		if err := ctx.Err(); err != nil {
+			return resource.NonRetryableError(err)
+		}
 		clusters, err := emrService.DescribeInstancesById(ctx, instanceId, DisplayStrategyIsclusterList)
 
 		if e, ok := err.(*errors.TencentCloudSDKError); ok {
@@ -446,14 +595,27 @@ func resourceTencentCloudEmrClusterDelete(d *schema.ResourceData, meta interface
 		return nil
 	})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if metaDB != nil && *metaDB != "" {
+		// a shared metadb is still used by other clusters, only offline the dedicated one
+		shared, err := emrService.IsMetaDbShared(ctx, d, instanceId, *metaDB)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if shared {
+			log.Printf("[DEBUG]%s metadb [%s] of EMR cluster [%s] is shared, skip offline it\n", logId, *metaDB, instanceId)
+			return nil
+		}
+
 		// remove metadb
 		mysqlService := MysqlService{client: meta.(*TencentCloudClient).apiV3Conn}
 
-		err = resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+		err = resource.RetryContext(ctx, writeRetryTimeout, func() *resource.RetryError {
+			if err := ctx.Err(); err != nil {
+				return resource.NonRetryableError(err)
+			}
 			err := mysqlService.OfflineIsolatedInstances(ctx, *metaDB)
 			if err != nil {
 				return retryError(err, InternalError)
@@ -462,24 +624,30 @@ func resourceTencentCloudEmrClusterDelete(d *schema.ResourceData, meta interface
 		})
 
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	return nil
 }
 
-func resourceTencentCloudEmrClusterRead(d *schema.ResourceData, meta interface{}) error {
+func resourceTencentCloudEmrClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	logId := getLogId(contextNil)
-	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+	ctx = context.WithValue(ctx, logIdKey, logId)
 	emrService := EMRService{
 		client: meta.(*TencentCloudClient).apiV3Conn,
 	}
 	instanceId := d.Id()
-	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
-		_, err := emrService.DescribeInstancesById(ctx, instanceId, DisplayStrategyIsclusterList)
+	var clusters []*emr.ClusterInstancesInfo
+	notFound := false
+	err := resource.RetryContext(ctx, readRetryTimeout, func() *resource.RetryError {
+		if err := ctx.Err(); err != nil {
+			return resource.NonRetryableError(err)
+		}
+		result, err := emrService.DescribeInstancesById(ctx, instanceId, DisplayStrategyIsclusterList)
 
 		if e, ok := err.(*errors.TencentCloudSDKError); ok {
 			if e.GetCode() == "InternalError.ClusterNotFound" {
+				notFound = true
 				return nil
 			}
 		}
@@ -487,18 +655,353 @@ func resourceTencentCloudEmrClusterRead(d *schema.ResourceData, meta interface{}
 		if err != nil {
 			return resource.RetryableError(err)
 		}
+		clusters = result
 		return nil
 	})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if notFound || len(clusters) == 0 {
+		log.Printf("[WARN]%s resource `EmrCluster` [%s] not found, please check if it has been deleted.\n", logId, instanceId)
+		d.SetId("")
+		return nil
+	}
+
+	// ClusterInstancesInfo has no renew flag, it is read from the master nodes of the prepaid cluster
+	if d.Get("pay_mode").(int) == 1 {
+		var nodes []*emr.NodeHardwareInfo
+		err := resource.RetryContext(ctx, readRetryTimeout, func() *resource.RetryError {
+			if err := ctx.Err(); err != nil {
+				return resource.NonRetryableError(err)
+			}
+			result, e := emrService.DescribeClusterNodes(ctx, instanceId, EMR_NODE_FLAG_MASTER, EMR_HARDWARE_RESOURCE_TYPE_ALL, 0, EMR_DESCRIBE_CLUSTER_NODE_LIMIT)
+			if e != nil {
+				// the InternalError prefix below would retry it until the timeout
+				if sdkErr, ok := e.(*errors.TencentCloudSDKError); ok && sdkErr.GetCode() == "InternalError.ClusterNotFound" {
+					notFound = true
+					return nil
+				}
+				return retryError(e, InternalError)
+			}
+			nodes = result
+			return nil
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if notFound {
+			log.Printf("[WARN]%s resource `EmrCluster` [%s] not found, please check if it has been deleted.\n", logId, instanceId)
+			d.SetId("")
+			return nil
+		}
+		if autoRenew, ok := emrClusterAutoRenew(nodes); ok {
+			_ = d.Set("auto_renew", autoRenew)
+		}
+	}
+
+	// display_strategy is only a query option of DescribeInstances and can not be read back
+	if _, ok := d.GetOk("display_strategy"); !ok {
+		_ = d.Set("display_strategy", DisplayStrategyIsclusterList)
 	}
 
 	tagService := TagService{client: meta.(*TencentCloudClient).apiV3Conn}
 	region := meta.(*TencentCloudClient).apiV3Conn.Region
 	tags, err := tagService.DescribeResourceTags(ctx, "emr", "emr-instance", region, d.Id())
 	if err != nil {
-		return err
+		// the cluster itself is readable, do not break refresh when the caller has no tag permissions
+		if sdkErr := helper.UnwarpSDKError(err); sdkErr != nil && isExpectError(sdkErr, TAG_AUTH_ERROR_CODES) {
+			log.Printf("[WARN]%s read EMR cluster [%s] tags failed, tags will be left unset, reason:%s\n", logId, instanceId, err.Error())
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 	_ = d.Set("tags", tags)
 	return nil
 }
+
+func resourceTencentCloudEmrClusterVpcSettingsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("vpc_settings") {
+		return nil
+	}
+
+	vpcSettings := d.Get("vpc_settings").(map[string]interface{})
+	for k := range vpcSettings {
+		if !IsContains(EMR_VPC_SETTINGS_KEYS, k) {
+			return fmt.Errorf("vpc_settings: unsupported key `%s`, only %s are allowed", k, strings.Join(EMR_VPC_SETTINGS_KEYS, ", "))
+		}
+	}
+	for _, k := range EMR_VPC_SETTINGS_KEYS {
+		if v, ok := vpcSettings[k]; !ok || v.(string) == "" {
+			return fmt.Errorf("vpc_settings: key `%s` is required", k)
+		}
+	}
+
+	return nil
+}
+
+// resourceTencentCloudEmrClusterProductSoftwaresDiff checks the softwares against the product family of product_id,
+// it is skipped until both are known.
+func resourceTencentCloudEmrClusterProductSoftwaresDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("product_id") || !d.NewValueKnown("softwares") {
+		return nil
+	}
+	return checkEmrClusterProductSoftwares(d.Get("product_id").(int), d.Get("softwares").([]interface{}))
+}
+
+// checkEmrClusterProductSoftwares returns an error if a software is not a component of the product family of the product id.
+func checkEmrClusterProductSoftwares(productId int, softwares []interface{}) error {
+	family, ok := EMR_PRODUCT_FAMILIES[productId]
+	if !ok {
+		return nil
+	}
+	components, ok := EMR_PRODUCT_FAMILY_COMPONENTS[family]
+	if !ok {
+		return nil
+	}
+	for _, v := range softwares {
+		software, _ := v.(string)
+		if !IsContains(components, emrSoftwareComponent(software)) {
+			return fmt.Errorf("softwares: `%s` is not a component of the %s product family of product_id %d, valid components: %s",
+				software, family, productId, strings.Join(components, ", "))
+		}
+	}
+	return nil
+}
+
+var emrSoftwareVersionRegexp = regexp.MustCompile(`-\d`)
+
+// emrSoftwareComponent returns the component of a software, such as `kafka` of `kafka-2.4.1`.
+func emrSoftwareComponent(software string) string {
+	if loc := emrSoftwareVersionRegexp.FindStringIndex(software); loc != nil {
+		return software[:loc[0]]
+	}
+	return software
+}
+
+// resourceTencentCloudEmrClusterSoftwaresDiff checks the softwares against the target zone by a price inquiry
+// of the cluster, so zone-specific component mismatches fail the plan instead of the long create.
+// It is skipped when the inquiry fails for other reasons.
+func resourceTencentCloudEmrClusterSoftwaresDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("validate_softwares").(bool) {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("softwares") && !d.HasChange("placement") {
+		return nil
+	}
+	for _, k := range []string{"product_id", "softwares", "placement", "resource_spec", "vpc_settings"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	placement := d.Get("placement").(map[string]interface{})
+	zone, _ := placement["zone"].(string)
+	if zone == "" {
+		return nil
+	}
+
+	request := emr.NewInquiryPriceCreateInstanceRequest()
+	request.ProductId = helper.IntUint64(d.Get("product_id").(int))
+	request.SupportHA = helper.IntUint64(d.Get("support_ha").(int))
+	request.PayMode = helper.IntUint64(d.Get("pay_mode").(int))
+	request.TimeSpan = helper.IntUint64(d.Get("time_span").(int))
+	request.TimeUnit = helper.String(d.Get("time_unit").(string))
+	request.Placement = &emr.Placement{Zone: helper.String(zone), ProjectId: helper.IntInt64(0)}
+	if projectId, ok := placement["project_id"].(string); ok {
+		request.Placement.ProjectId = helper.StrToInt64Point(projectId)
+	}
+	for _, software := range d.Get("softwares").([]interface{}) {
+		request.Software = append(request.Software, helper.String(software.(string)))
+	}
+	if resourceSpec, ok := d.Get("resource_spec").([]interface{}); ok && len(resourceSpec) > 0 && resourceSpec[0] != nil {
+		request.ResourceSpec = ParseResourceSpec(resourceSpec[0].(map[string]interface{}))
+	}
+	vpcSettings := d.Get("vpc_settings").(map[string]interface{})
+	vpcId, _ := vpcSettings[EMR_VPC_SETTINGS_KEY_VPC_ID].(string)
+	subnetId, _ := vpcSettings[EMR_VPC_SETTINGS_KEY_SUBNET_ID].(string)
+	request.VPCSettings = &emr.VPCSettings{VpcId: helper.String(vpcId), SubnetId: helper.String(subnetId)}
+
+	logId := getLogId(contextNil)
+	ctx = context.WithValue(ctx, logIdKey, logId)
+	emrService := EMRService{client: meta.(*TencentCloudClient).apiV3Conn}
+
+	err := emrService.InquiryPriceCreateInstance(ctx, request)
+	if err == nil {
+		return nil
+	}
+	if sdkErr := helper.UnwarpSDKError(err); sdkErr != nil && isExpectError(sdkErr, EMR_SOFTWARE_UNAVAILABLE_ERROR_CODES) {
+		return fmt.Errorf("softwares: can not be deployed in zone `%s`, reason: %s", zone, sdkErr.GetMessage())
+	}
+	log.Printf("[WARN]%s skip softwares availability check of EMR cluster in zone [%s], reason:%s\n", logId, zone, err.Error())
+	return nil
+}
+
+// resourceTencentCloudEmrClusterQuotaDiff fails the plan when the remaining CVM quota of the zone can not hold
+// the nodes to be added, instead of failing on the Nth node of a long create or scale out.
+// It is skipped when the quota can not be queried.
+func resourceTencentCloudEmrClusterQuotaDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("validate_quota").(bool) {
+		return nil
+	}
+	if !d.NewValueKnown("placement") || !d.NewValueKnown("resource_spec") || !d.NewValueKnown("pay_mode") {
+		return nil
+	}
+
+	placement := d.Get("placement").(map[string]interface{})
+	zone, _ := placement["zone"].(string)
+	if zone == "" {
+		return nil
+	}
+
+	var requested int
+	for _, k := range []string{"master_count", "core_count", "task_count", "common_count"} {
+		key := "resource_spec.0." + k
+		o, n := d.GetChange(key)
+		if d.Id() == "" {
+			o = 0
+		}
+		if increase := n.(int) - o.(int); increase > 0 {
+			requested += increase
+		}
+	}
+	if requested == 0 {
+		return nil
+	}
+
+	logId := getLogId(contextNil)
+	ctx = context.WithValue(ctx, logIdKey, logId)
+	cvmService := CvmService{client: meta.(*TencentCloudClient).apiV3Conn}
+
+	prepaid := d.Get("pay_mode").(int) == 1
+	remaining, found, err := cvmService.DescribeZoneRemainingQuota(ctx, zone, prepaid)
+	if err != nil || !found {
+		reason := "no quota found"
+		if err != nil {
+			reason = err.Error()
+		}
+		log.Printf("[WARN]%s skip quota check of EMR cluster in zone [%s], reason:%s\n", logId, zone, reason)
+		return nil
+	}
+
+	if int64(requested) > remaining {
+		return fmt.Errorf("resource_spec: %d nodes are requested in zone `%s`, but only %d instances are left in the CVM quota", requested, zone, remaining)
+	}
+	return nil
+}
+
+func resourceTencentCloudEmrClusterDiskEncryptDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("disk_encrypt").(bool) || !d.NewValueKnown("resource_spec") {
+		return nil
+	}
+	resourceSpec, ok := d.Get("resource_spec").([]interface{})
+	if !ok || len(resourceSpec) == 0 || resourceSpec[0] == nil {
+		return nil
+	}
+	return checkEmrClusterDiskEncrypt(resourceSpec[0].(map[string]interface{}))
+}
+
+// checkEmrClusterDiskEncrypt returns an error if any node spec uses a disk type which can not be encrypted.
+func checkEmrClusterDiskEncrypt(resourceSpec map[string]interface{}) error {
+	for _, k := range []string{"master_resource_spec", "core_resource_spec", "task_resource_spec", "common_resource_spec"} {
+		specs, ok := resourceSpec[k].([]interface{})
+		if !ok || len(specs) == 0 || specs[0] == nil {
+			continue
+		}
+		diskType, _ := specs[0].(map[string]interface{})["disk_type"].(string)
+		if IsContains(EMR_CBS_ENCRYPT_UNSUPPORTED_DISK_TYPES, diskType) {
+			return fmt.Errorf("resource_spec.0.%s: disk_type `%s` does not support encryption, which is required by disk_encrypt", k, diskType)
+		}
+	}
+	return nil
+}
+
+// emrClusterAutoRenew returns the auto_renew value of the cluster from the renew flag of its nodes, ok is false if no
+// node reports it.
+func emrClusterAutoRenew(nodes []*emr.NodeHardwareInfo) (autoRenew int, ok bool) {
+	for _, node := range nodes {
+		if node == nil || node.IsAutoRenew == nil {
+			continue
+		}
+		if *node.IsAutoRenew == 1 {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// resourceTencentCloudEmrClusterAutoRenewDiff rejects auto_renew changes at plan time, EMR has no API to modify the
+// renew flag and failing in Update would leave the other changes partially applied.
+func resourceTencentCloudEmrClusterAutoRenewDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && d.HasChange("auto_renew") {
+		return fmt.Errorf("auto_renew can not be modified after creation")
+	}
+	return nil
+}
+
+// resourceTencentCloudEmrClusterResourceSpecDiff replaces the cluster only for the resource_spec changes which can not be
+// done online, and rejects the count decreases which the scaling API does not support. With mixed changes the cluster
+// is replaced anyway, so the decreases are not rejected then.
+func resourceTencentCloudEmrClusterResourceSpecDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown("resource_spec") {
+		return nil
+	}
+
+	// ForceNew on a nested block only marks its count, so the changed leaf fields are marked one by one
+	replace := false
+	for _, key := range emrClusterResourceSpecReplaceKeys() {
+		if !d.HasChange(key) {
+			continue
+		}
+		if err := d.ForceNew(key); err != nil {
+			return err
+		}
+		replace = true
+	}
+	if replace {
+		return nil
+	}
+
+	for _, k := range []string{"master_count", "core_count"} {
+		o, n := d.GetChange("resource_spec.0." + k)
+		if err := checkEmrClusterCountChange(k, o.(int), n.(int)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// emrClusterResourceSpecReplaceKeys returns the full keys of EMR_RESOURCE_SPEC_REPLACE_KEYS, expanded to the leaf fields
+// of the nested resource specs. The block counts are kept, so adding or removing a whole spec replaces the cluster too.
+func emrClusterResourceSpecReplaceKeys() []string {
+	specFields := buildResourceSpecSchema().Elem.(*schema.Resource).Schema
+
+	keys := make([]string, 0)
+	for _, k := range EMR_RESOURCE_SPEC_REPLACE_KEYS {
+		key := "resource_spec.0." + k
+		if !strings.HasSuffix(k, "_resource_spec") {
+			keys = append(keys, key)
+			continue
+		}
+		keys = append(keys, key+".#")
+		for field := range specFields {
+			keys = append(keys, key+".0."+field)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// checkEmrClusterCountChange returns an error if the node count can not be changed online.
+func checkEmrClusterCountChange(k string, o, n int) error {
+	if n >= o {
+		return nil
+	}
+	switch k {
+	case "master_count":
+		return fmt.Errorf("resource_spec.0.master_count can not be decreased from %d to %d, EMR does not support scaling in master nodes", o, n)
+	case "core_count":
+		return fmt.Errorf("resource_spec.0.core_count can not be decreased from %d to %d, EMR only supports scaling in task nodes", o, n)
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
 	emr "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/emr/v20190103"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 )
 
 func init() {
@@ -108,6 +109,7 @@ var testEmrClusterResourceKey = "tencentcloud_emr_cluster.emrrrr"
 
 func TestAccTencentCloudEmrClusterResource(t *testing.T) {
 	t.Parallel()
+	var instanceId string
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheckCommon(t, ACCOUNT_TYPE_COMMON) },
 		Providers: testAccProviders,
@@ -133,12 +135,234 @@ func TestAccTencentCloudEmrClusterResource(t *testing.T) {
 					resource.TestCheckResourceAttrSet(testEmrClusterResourceKey, "instance_id"),
 					resource.TestCheckResourceAttr(testEmrClusterResourceKey, "sg_id", defaultEMRSgId),
 					resource.TestCheckResourceAttr(testEmrClusterResourceKey, "tags.emr-key", "emr-value"),
+					func(s *terraform.State) error {
+						instanceId = s.RootModule().Resources[testEmrClusterResourceKey].Primary.ID
+						return nil
+					},
 				),
 			},
+			{
+				// tags changed outside of terraform must show up as drift
+				PreConfig: func() {
+					if err := testAccModifyEmrClusterTags(instanceId, map[string]string{"emr-key": "emr-drift"}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testEmrBasic,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
 		},
 	})
 }
 
+func TestCheckEmrClusterDiskEncrypt(t *testing.T) {
+	spec := func(diskType string) []interface{} {
+		return []interface{}{map[string]interface{}{"disk_type": diskType}}
+	}
+
+	if err := checkEmrClusterDiskEncrypt(map[string]interface{}{
+		"master_resource_spec": spec("CLOUD_PREMIUM"),
+		"core_resource_spec":   spec("CLOUD_SSD"),
+	}); err != nil {
+		t.Fatalf("cloud disks should support encryption, got: %v", err)
+	}
+
+	err := checkEmrClusterDiskEncrypt(map[string]interface{}{
+		"master_resource_spec": spec("CLOUD_PREMIUM"),
+		"core_resource_spec":   spec("LOCAL_SSD"),
+	})
+	if err == nil || !strings.Contains(err.Error(), "core_resource_spec") {
+		t.Fatalf("expected an error on core_resource_spec, got: %v", err)
+	}
+}
+
+func TestCheckEmrClusterCountChange(t *testing.T) {
+	cases := []struct {
+		key    string
+		o, n   int
+		reject bool
+	}{
+		{"master_count", 1, 2, false},
+		{"master_count", 2, 1, true},
+		{"core_count", 2, 3, false},
+		{"core_count", 3, 2, true},
+		{"task_count", 3, 1, false},
+	}
+	for _, c := range cases {
+		err := checkEmrClusterCountChange(c.key, c.o, c.n)
+		if (err != nil) != c.reject {
+			t.Errorf("%s from %d to %d: expected reject %v, got %v", c.key, c.o, c.n, c.reject, err)
+		}
+	}
+}
+
+func TestEmrClusterResourceSpecDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "emr-xxxxxxxx",
+		Attributes: map[string]string{
+			"id":                                     "emr-xxxxxxxx",
+			"product_id":                             "4",
+			"support_ha":                             "0",
+			"instance_name":                          "emr-cluster-test",
+			"pay_mode":                               "0",
+			"time_span":                              "3600",
+			"time_unit":                              "s",
+			"resource_spec.#":                        "1",
+			"resource_spec.0.master_resource_spec.#": "1",
+			"resource_spec.0.master_resource_spec.0.spec":         "CVM.S5",
+			"resource_spec.0.master_resource_spec.0.disk_type":    "CLOUD_PREMIUM",
+			"resource_spec.0.master_resource_spec.0.disk_size":    "100",
+			"resource_spec.0.master_resource_spec.0.storage_type": "5",
+			"resource_spec.0.master_count":                        "1",
+			"resource_spec.0.core_count":                          "2",
+		},
+	}
+	masterResourceSpec := func(spec, diskType string) []interface{} {
+		return []interface{}{map[string]interface{}{
+			"spec":         spec,
+			"disk_type":    diskType,
+			"disk_size":    100,
+			"storage_type": 5,
+		}}
+	}
+	cases := []struct {
+		name        string
+		spec        []interface{}
+		coreCount   int
+		key         string
+		requiresNew bool
+	}{
+		{"disk_type", masterResourceSpec("CVM.S5", "CLOUD_SSD"), 2, "resource_spec.0.master_resource_spec.0.disk_type", true},
+		{"spec", masterResourceSpec("CVM.S6", "CLOUD_PREMIUM"), 2, "resource_spec.0.master_resource_spec.0.spec", true},
+		{"core_count", masterResourceSpec("CVM.S5", "CLOUD_PREMIUM"), 3, "resource_spec.0.core_count", false},
+	}
+	for _, c := range cases {
+		raw := map[string]interface{}{
+			"product_id":    4,
+			"vpc_settings":  map[string]interface{}{"vpc_id": "vpc-xxxxxxxx", "subnet_id": "subnet-xxxxxxxx"},
+			"softwares":     []interface{}{"zookeeper-3.6.1"},
+			"support_ha":    0,
+			"instance_name": "emr-cluster-test",
+			"pay_mode":      0,
+			"placement":     map[string]interface{}{"zone": "ap-guangzhou-3", "project_id": 0},
+			"time_span":     3600,
+			"time_unit":     "s",
+			"login_settings": map[string]interface{}{
+				"password": "Tencent@cloud123",
+			},
+			"resource_spec": []interface{}{map[string]interface{}{
+				"master_resource_spec": c.spec,
+				"master_count":         1,
+				"core_count":           c.coreCount,
+			}},
+		}
+		diff, err := resourceTencentCloudEmrCluster().Diff(context.TODO(), state, terraform.NewResourceConfigRaw(raw), nil)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if diff == nil || diff.Attributes[c.key] == nil {
+			t.Fatalf("%s: expected a diff on %s, got %v", c.name, c.key, diff)
+		}
+		if got := diff.Attributes[c.key].RequiresNew; got != c.requiresNew {
+			t.Errorf("%s: expected %s to require new %v, got %v", c.name, c.key, c.requiresNew, got)
+		}
+		if !c.requiresNew {
+			for k, v := range diff.Attributes {
+				if strings.HasPrefix(k, "resource_spec.") && v.RequiresNew {
+					t.Errorf("%s: expected %s to be updated in place", c.name, k)
+				}
+			}
+		}
+	}
+}
+
+func TestCheckEmrClusterProductSoftwares(t *testing.T) {
+	cases := []struct {
+		productId int
+		softwares []interface{}
+		reject    bool
+	}{
+		{27, []interface{}{"zookeeper-3.4.9", "kafka-2.4.1"}, false},
+		{27, []interface{}{"zookeeper-3.4.9", "hdfs-2.8.5"}, true},
+		{36, []interface{}{"starrocks-1.2.1"}, false},
+		{39, []interface{}{"starrocks-1.2.1", "kafka-2.4.1"}, true},
+		{33, []interface{}{"hdfs-2.8.5", "spark_hadoop2.8-3.0.2"}, false},
+		{4, []interface{}{"zookeeper-3.6.1"}, false},
+	}
+	for _, c := range cases {
+		err := checkEmrClusterProductSoftwares(c.productId, c.softwares)
+		if (err != nil) != c.reject {
+			t.Errorf("product_id %d with %v: expected reject %v, got %v", c.productId, c.softwares, c.reject, err)
+		}
+	}
+
+	if component := emrSoftwareComponent("spark_hadoop2.8-3.0.2"); component != "spark_hadoop2.8" {
+		t.Errorf("expected component spark_hadoop2.8, got %s", component)
+	}
+}
+
+func TestEmrClusterAutoRenew(t *testing.T) {
+	cases := []struct {
+		nodes     []*emr.NodeHardwareInfo
+		autoRenew int
+		ok        bool
+	}{
+		{nil, 0, false},
+		{[]*emr.NodeHardwareInfo{{}}, 0, false},
+		{[]*emr.NodeHardwareInfo{{IsAutoRenew: helper.IntInt64(1)}}, 1, true},
+		{[]*emr.NodeHardwareInfo{{IsAutoRenew: helper.IntInt64(0)}}, 0, true},
+		{[]*emr.NodeHardwareInfo{{}, {IsAutoRenew: helper.IntInt64(1)}}, 1, true},
+	}
+	for i, c := range cases {
+		autoRenew, ok := emrClusterAutoRenew(c.nodes)
+		if autoRenew != c.autoRenew || ok != c.ok {
+			t.Errorf("case %d: expected (%d, %v), got (%d, %v)", i, c.autoRenew, c.ok, autoRenew, ok)
+		}
+	}
+}
+
+func TestEmrMetaDbUsedByOtherClusters(t *testing.T) {
+	cluster := func(id, metaDb string) *emr.ClusterInstancesInfo {
+		return &emr.ClusterInstancesInfo{ClusterId: helper.String(id), MetaDb: helper.String(metaDb)}
+	}
+	// offset is a page number, the shared metadb only shows up on the second page
+	pages := map[uint64][]*emr.ClusterInstancesInfo{
+		0: {cluster("emr-self", "cdb-shared"), cluster("emr-a", "cdb-a")},
+		1: {cluster("emr-b", "cdb-b"), cluster("emr-c", "cdb-shared")},
+		2: {cluster("emr-d", "cdb-d")},
+	}
+	describe := func(offset uint64) ([]*emr.ClusterInstancesInfo, error) {
+		return pages[offset], nil
+	}
+
+	shared, err := emrMetaDbUsedByOtherClusters("emr-self", "cdb-shared", 2, describe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shared {
+		t.Errorf("expected the metadb used by emr-c on the second page to be shared")
+	}
+
+	shared, err = emrMetaDbUsedByOtherClusters("emr-self", "cdb-self-only", 2, describe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shared {
+		t.Errorf("expected the metadb not to be shared")
+	}
+}
+
+func testAccModifyEmrClusterTags(instanceId string, tags map[string]string) error {
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+	client := testAccProvider.Meta().(*TencentCloudClient).apiV3Conn
+	tagService := TagService{client: client}
+	resourceName := BuildTagResourceName("emr", "emr-instance", client.Region, instanceId)
+	return tagService.ModifyTags(ctx, resourceName, tags, nil)
+}
+
 func testAccCheckEmrExists(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
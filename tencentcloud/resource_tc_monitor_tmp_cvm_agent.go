@@ -1,6 +1,8 @@
 /*
 Provides a resource to create a monitor tmpCvmAgent
 
+~> **NOTE:** The monitor API does not support deleting a Prometheus CVM agent, destroying this resource disables the agent instead.
+
 Example Usage
 
 ```hcl
@@ -88,6 +90,18 @@ func resourceTencentCloudMonitorTmpCvmAgent() *schema.Resource {
 				Computed:    true,
 				Description: "Agent id.",
 			},
+
+			"status": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Agent status.",
+			},
+
+			"last_heartbeat_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time of the last heartbeat of the agent, empty if the agent has not reported any heartbeat yet, such as a newly created agent.",
+			},
 		},
 	}
 }
@@ -146,9 +160,9 @@ func resourceTencentCloudMonitorTmpCvmAgentRead(d *schema.ResourceData, meta int
 
 	service := MonitorService{client: meta.(*TencentCloudClient).apiV3Conn}
 
-	ids := strings.Split(d.Id(), FILED_SP)
-	if len(ids) != 2 {
-		return fmt.Errorf("id is broken, id is %s", d.Id())
+	ids, err := helper.ParseCompositeId(d.Id(), 2, "instanceId", "agentId")
+	if err != nil {
+		return err
 	}
 
 	tmpCvmAgent, err := service.DescribeMonitorTmpCvmAgent(ctx, ids[0], ids[1])
@@ -174,6 +188,12 @@ func resourceTencentCloudMonitorTmpCvmAgentRead(d *schema.ResourceData, meta int
 		_ = d.Set("agent_id", tmpCvmAgent.AgentId)
 	}
 
+	if tmpCvmAgent.Status != nil {
+		_ = d.Set("status", tmpCvmAgent.Status)
+	}
+
+	_ = d.Set("last_heartbeat_time", helper.PString(tmpCvmAgent.HeartbeatTime))
+
 	return nil
 }
 
@@ -181,5 +201,34 @@ func resourceTencentCloudMonitorTmpCvmAgentDelete(d *schema.ResourceData, meta i
 	defer logElapsed("resource.tencentcloud_monitor_tmp_cvm_agent.delete")()
 	defer inconsistentCheck(d, meta)()
 
+	logId := getLogId(contextNil)
+
+	ids, err := helper.ParseCompositeId(d.Id(), 2, "instanceId", "agentId")
+	if err != nil {
+		return err
+	}
+
+	// there is no api to delete an agent, disable it instead
+	request := monitor.NewUpdatePrometheusAgentStatusRequest()
+	request.InstanceId = helper.String(ids[0])
+	request.AgentIds = []*string{helper.String(ids[1])}
+	request.Status = helper.IntInt64(MONITOR_TMP_CVM_AGENT_STATUS_DISABLED)
+
+	err = resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+		result, e := meta.(*TencentCloudClient).apiV3Conn.UseMonitorClient().UpdatePrometheusAgentStatus(request)
+		if e != nil {
+			return retryError(e)
+		} else {
+			log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+				logId, request.GetAction(), request.ToJsonString(), result.ToJsonString())
+		}
+		return nil
+	})
+
+	if err != nil {
+		log.Printf("[CRITAL]%s delete monitor tmpCvmAgent failed, reason:%+v", logId, err)
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,103 @@
+package tencentcloud
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
+)
+
+func TestAccTencentCloudMonitorTmpCvmAgent_basic(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckCommon(t, ACCOUNT_TYPE_COMMON) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTmpCvmAgentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testTmpCvmAgent_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTmpCvmAgentExists("tencentcloud_monitor_tmp_cvm_agent.basic"),
+					resource.TestCheckResourceAttr("tencentcloud_monitor_tmp_cvm_agent.basic", "instance_id", defaultPrometheusId),
+					resource.TestCheckResourceAttr("tencentcloud_monitor_tmp_cvm_agent.basic", "name", "tf-agent"),
+					resource.TestCheckResourceAttrSet("tencentcloud_monitor_tmp_cvm_agent.basic", "agent_id"),
+				),
+			},
+			{
+				ResourceName:      "tencentcloud_monitor_tmp_cvm_agent.basic",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckTmpCvmAgentDestroy(s *terraform.State) error {
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+	service := MonitorService{client: testAccProvider.Meta().(*TencentCloudClient).apiV3Conn}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "tencentcloud_monitor_tmp_cvm_agent" {
+			continue
+		}
+
+		ids, err := helper.ParseCompositeId(rs.Primary.ID, 2, "instanceId", "agentId")
+		if err != nil {
+			return err
+		}
+
+		tmpCvmAgent, err := service.DescribeMonitorTmpCvmAgent(ctx, ids[0], ids[1])
+		if err != nil {
+			return err
+		}
+
+		// agents can not be deleted, destroy disables them
+		if tmpCvmAgent != nil && tmpCvmAgent.Status != nil && *tmpCvmAgent.Status != MONITOR_TMP_CVM_AGENT_STATUS_DISABLED {
+			return fmt.Errorf("cvm agent %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckTmpCvmAgentExists(r string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		logId := getLogId(contextNil)
+		ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+		rs, ok := s.RootModule().Resources[r]
+		if !ok {
+			return fmt.Errorf("resource %s is not found", r)
+		}
+
+		ids, err := helper.ParseCompositeId(rs.Primary.ID, 2, "instanceId", "agentId")
+		if err != nil {
+			return err
+		}
+
+		service := MonitorService{client: testAccProvider.Meta().(*TencentCloudClient).apiV3Conn}
+		tmpCvmAgent, err := service.DescribeMonitorTmpCvmAgent(ctx, ids[0], ids[1])
+		if err != nil {
+			return err
+		}
+
+		if tmpCvmAgent == nil {
+			return fmt.Errorf("cvm agent %s is not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+const testTmpCvmAgent_basic = `
+variable "prometheus_id" {
+  default = "` + defaultPrometheusId + `"
+}
+
+resource "tencentcloud_monitor_tmp_cvm_agent" "basic" {
+  instance_id = var.prometheus_id
+  name        = "tf-agent"
+}`
@@ -34,6 +34,60 @@ resource "tencentcloud_nat_gateway" "example" {
 }
 ```
 
+Create a NAT gateway in a subnet.
+
+```hcl
+resource "tencentcloud_subnet" "subnet" {
+  vpc_id            = tencentcloud_vpc.vpc.id
+  name              = "tf_nat_gateway_subnet"
+  cidr_block        = "10.0.1.0/24"
+  availability_zone = "ap-guangzhou-3"
+}
+
+resource "tencentcloud_nat_gateway" "example" {
+  name             = "tf_example_nat_gateway"
+  vpc_id           = tencentcloud_vpc.vpc.id
+  subnet_id        = tencentcloud_subnet.subnet.id
+  zone             = "ap-guangzhou-3"
+  assigned_eip_set = [
+    tencentcloud_eip.eip_example1.public_ip,
+  ]
+}
+```
+
+Create a NAT gateway which allocates two more EIPs by itself, only the allocated EIPs are released when the NAT gateway is destroyed.
+
+```hcl
+resource "tencentcloud_nat_gateway" "example" {
+  name                   = "tf_example_nat_gateway"
+  vpc_id                 = tencentcloud_vpc.vpc.id
+  eip_address_count      = 2
+  release_eips_on_delete = true
+  assigned_eip_set = [
+    tencentcloud_eip.eip_example1.public_ip,
+  ]
+}
+```
+
+Create a NAT gateway with custom timeouts, attaching many EIPs may take a long time.
+
+```hcl
+resource "tencentcloud_nat_gateway" "example" {
+  name             = "tf_example_nat_gateway"
+  vpc_id           = tencentcloud_vpc.vpc.id
+  assigned_eip_set = [
+    tencentcloud_eip.eip_example1.public_ip,
+    tencentcloud_eip.eip_example2.public_ip,
+  ]
+
+  timeouts {
+    create = "10m"
+    update = "20m"
+    delete = "10m"
+  }
+}
+```
+
 Import
 
 NAT gateway can be imported using the id, e.g.
@@ -48,8 +102,9 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
@@ -58,13 +113,22 @@ import (
 
 func resourceTencentCloudNatGateway() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTencentCloudNatGatewayCreate,
-		Read:   resourceTencentCloudNatGatewayRead,
-		Update: resourceTencentCloudNatGatewayUpdate,
-		Delete: resourceTencentCloudNatGatewayDelete,
+		CreateContext: resourceTencentCloudNatGatewayCreate,
+		ReadContext:   resourceTencentCloudNatGatewayRead,
+		UpdateContext: resourceTencentCloudNatGatewayUpdate,
+		DeleteContext: resourceTencentCloudNatGatewayDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(readRetryTimeout),
+			Update: schema.DefaultTimeout(writeRetryTimeout),
+			Delete: schema.DefaultTimeout(writeRetryTimeout),
+		},
+		CustomizeDiff: customdiff.All(
+			resourceTencentCloudNatGatewayEipSetDiff,
+			resourceTencentCloudNatGatewayBandwidthDiff,
+		),
 
 		Schema: map[string]*schema.Schema{
 			"vpc_id": {
@@ -80,17 +144,25 @@ func resourceTencentCloudNatGateway() *schema.Resource {
 				Description:  "Name of the NAT gateway.",
 			},
 			"max_concurrent": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Default:      1000000,
-				ValidateFunc: validateAllowedIntValue([]int{1000000, 3000000, 10000000}),
-				Description:  "The upper limit of concurrent connection of NAT gateway. Valid values: `1000000`, `3000000`, `10000000`. Default is `1000000`.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          1000000,
+				ValidateFunc:     validateAllowedIntValue([]int{1000000, 3000000, 10000000}),
+				DiffSuppressFunc: natGatewayStandardDiffSuppress,
+				Description:      "The upper limit of concurrent connection of NAT gateway. Valid values: `1000000`, `3000000`, `10000000`. Default is `1000000`. Ignored by the standard NAT gateway.",
 			},
 			"bandwidth": {
-				Type:        schema.TypeInt,
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          100,
+				DiffSuppressFunc: natGatewayStandardDiffSuppress,
+				Description:      "The maximum public network output bandwidth of NAT gateway (unit: Mbps). Valid values: `20`, `50`, `100`, `200`, `500`, `1000`, `2000`, `5000`. Default is 100. Ignored by the standard NAT gateway.",
+			},
+			"bandwidth_usage_check": {
+				Type:        schema.TypeBool,
 				Optional:    true,
-				Default:     100,
-				Description: "The maximum public network output bandwidth of NAT gateway (unit: Mbps). Valid values: `20`, `50`, `100`, `200`, `500`, `1000`, `2000`, `5000`. Default is 100.",
+				Default:     false,
+				Description: "Whether to fail the plan when `bandwidth` is lowered below the peak outbound bandwidth of the last 5 minutes. The check queries the flow monitor of the NAT gateway, and is skipped when the metrics are unavailable. Default is `false`.",
 			},
 			"assigned_eip_set": {
 				Type:     schema.TypeSet,
@@ -103,31 +175,127 @@ func resourceTencentCloudNatGateway() *schema.Resource {
 				MaxItems:    10,
 				Description: "EIP IP address set bound to the gateway. The value of at least 1 and at most 10.",
 			},
+			"eip_address_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIntegerInRange(1, NAT_EIP_MAX_LIMIT-1),
+				Description:  "Number of EIPs allocated by the NAT gateway itself on creation in addition to `assigned_eip_set`, they are exported in `allocated_eip_set` and tagged with `tags`. The total number of EIPs can not exceed 10.",
+			},
 			"zone": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
 				Description: "The availability zone, such as `ap-guangzhou-3`.",
 			},
+			"subnet_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "ID of the subnet the NAT gateway belongs to.",
+			},
+			"security_group_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "ID list of the security groups bound to the NAT gateway. Only valid for the standard NAT gateway.",
+			},
 			"tags": {
 				Type:        schema.TypeMap,
 				Optional:    true,
 				Description: "The available tags within this NAT gateway.",
 			},
+			"release_eips_on_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to release the EIPs of `allocated_eip_set` after the NAT gateway is deleted. The EIPs of `assigned_eip_set` and those bound to the gateway in other ways are never released. Default is `false`.",
+			},
+			"allocated_eip_set": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "EIPs allocated by the NAT gateway on creation according to `eip_address_count`, they are not part of `assigned_eip_set`. Empty after import.",
+			},
 			//computed
 			"created_time": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "Create time of the NAT gateway.",
 			},
+			"nat_product_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Product version of the NAT gateway, `1` for the traditional NAT gateway and `2` for the standard NAT gateway.",
+			},
+			"assigned_eip_detail": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Details of the EIPs bound to the NAT gateway.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the EIP.",
+						},
+						"public_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Public IP address of the EIP.",
+						},
+						"bandwidth": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Bandwidth of the EIP (unit: Mbps).",
+						},
+					},
+				},
+			},
+			"isp": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ISP line type of the NAT gateway, taken from its EIPs, such as `BGP`, `CMCC`, `CTCC` and `CUCC`.",
+			},
+			"snat_sources": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Subnets and instances bound to the NAT gateway by SNAT rules, including the ones managed outside Terraform. Empty when there is no SNAT rule.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"snat_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the SNAT rule.",
+						},
+						"resource_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the bound resource, `SUBNET` or `NETWORKINTERFACE`.",
+						},
+						"resource_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the bound subnet or network interface.",
+						},
+						"private_ip_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Private IP address of the bound network interface, or the CIDR block of the bound subnet.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
-func resourceTencentCloudNatGatewayCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceTencentCloudNatGatewayCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	defer logElapsed("resource.tencentcloud_nat_gateway.create")()
 
 	logId := getLogId(contextNil)
+	ctx = context.WithValue(ctx, logIdKey, logId)
 	request := vpc.NewCreateNatGatewayRequest()
 	vpcId := d.Get("vpc_id").(string)
 	natGatewayName := d.Get("name").(string)
@@ -147,10 +315,18 @@ func resourceTencentCloudNatGatewayCreate(d *schema.ResourceData, meta interface
 		}
 	}
 
+	if v, ok := d.GetOk("eip_address_count"); ok {
+		request.AddressCount = helper.IntUint64(v.(int))
+	}
+
 	if v, ok := d.GetOk("zone"); ok {
 		request.Zone = helper.String(v.(string))
 	}
 
+	if v, ok := d.GetOk("subnet_id"); ok {
+		request.SubnetId = helper.String(v.(string))
+	}
+
 	if v := helper.GetTags(d, "tags"); len(v) > 0 {
 		for tagKey, tagValue := range v {
 			tag := vpc.Tag{
@@ -161,8 +337,21 @@ func resourceTencentCloudNatGatewayCreate(d *schema.ResourceData, meta interface
 		}
 	}
 
+	vpcService := VpcService{client: meta.(*TencentCloudClient).apiV3Conn}
+	assignedEips := helper.InterfacesStrings(d.Get("assigned_eip_set").(*schema.Set).List())
+	conflicts, err := vpcService.CheckEipAvailable(ctx, assignedEips, "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := eipConflictsError(conflicts); err != nil {
+		return diag.FromErr(err)
+	}
+
 	var response *vpc.CreateNatGatewayResponse
-	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+	err = resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		if err := ctx.Err(); err != nil {
+			return resource.NonRetryableError(err)
+		}
 		result, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().CreateNatGateway(request)
 		if e != nil {
 			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
@@ -174,68 +363,93 @@ func resourceTencentCloudNatGatewayCreate(d *schema.ResourceData, meta interface
 	})
 	if err != nil {
 		log.Printf("[CRITAL]%s create NAT gateway failed, reason:%s\n", logId, err.Error())
-		return err
+		return diag.FromErr(err)
 	}
 
 	if len(response.Response.NatGatewaySet) < 1 {
-		return fmt.Errorf("NAT gateway ID is nil")
+		return diag.Errorf("NAT gateway ID is nil")
 	}
 	d.SetId(*response.Response.NatGatewaySet[0].NatGatewayId)
 
 	//cs::vpc:ap-guangzhou:uin/12345:nat/nat-nxxx
-	ctx := context.WithValue(context.TODO(), logIdKey, logId)
 	if tags := helper.GetTags(d, "tags"); len(tags) > 0 {
 		tcClient := meta.(*TencentCloudClient).apiV3Conn
 		tagService := &TagService{client: tcClient}
 		resourceName := BuildTagResourceName("vpc", "nat", tcClient.Region, d.Id())
 		if err := tagService.ModifyTags(ctx, resourceName, tags, nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	// must wait for finishing creating NAT
-	statRequest := vpc.NewDescribeNatGatewaysRequest()
-	statRequest.NatGatewayIds = []*string{response.Response.NatGatewaySet[0].NatGatewayId}
-	err = resource.Retry(readRetryTimeout, func() *resource.RetryError {
-		result, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().DescribeNatGateways(statRequest)
-		if e != nil {
-			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
-				logId, request.GetAction(), request.ToJsonString(), e.Error())
-			return retryError(e)
-		} else {
-			//if not, quit
-			if len(result.Response.NatGatewaySet) != 1 {
-				return resource.NonRetryableError(fmt.Errorf("creating error"))
-			}
-			//else get stat
-			nat := result.Response.NatGatewaySet[0]
-			stat := *nat.State
+	err = helper.WaitForState(ctx, vpcService.NatGatewayStateFunc(ctx, d.Id()),
+		[]string{NAT_AVAILABLE_STATE}, []string{NAT_FAILED_STATE, NAT_NOT_FOUND_STATE}, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		log.Printf("[CRITAL]%s create NAT gateway failed, reason:%s\n", logId, err.Error())
+		return diag.FromErr(err)
+	}
 
-			if stat == "AVAILABLE" {
-				return nil
+	// the EIPs allocated by the gateway are the ones it got besides assigned_eip_set
+	if _, ok := d.GetOk("eip_address_count"); ok {
+		var nat *vpc.NatGateway
+		err = resource.RetryContext(ctx, readRetryTimeout, func() *resource.RetryError {
+			if err := ctx.Err(); err != nil {
+				return resource.NonRetryableError(err)
 			}
-			return resource.RetryableError(fmt.Errorf("creating not ready retry"))
+			result, e := vpcService.DescribeNatGatewayById(ctx, d.Id())
+			if e != nil {
+				return retryError(e)
+			}
+			nat = result
+			return nil
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if nat != nil {
+			allocatedEips := natGatewayEipsExcept(nat, assignedEips)
+			_ = d.Set("allocated_eip_set", allocatedEips)
+
+			// the allocated EIPs inherit the tags of the gateway, the assigned ones belong to the user and are left untouched
+			if tags := helper.GetTags(d, "tags"); len(tags) > 0 {
+				tcClient := meta.(*TencentCloudClient).apiV3Conn
+				tagService := &TagService{client: tcClient}
+				for _, eipId := range natGatewayEipIds(nat, allocatedEips) {
+					resourceName := BuildTagResourceName(VPC_SERVICE_TYPE, EIP_RESOURCE_TYPE, tcClient.Region, eipId)
+					if err := tagService.ModifyTags(ctx, resourceName, tags, nil); err != nil {
+						return diag.FromErr(err)
+					}
+				}
+			}
+		}
+	}
+
+	// security groups can not be set on creation, bind them once the NAT gateway is available
+	if v, ok := d.GetOk("security_group_ids"); ok {
+		err = vpcService.ModifyNatGatewaySecurityGroups(ctx, d.Id(), helper.InterfacesStringsPoint(v.(*schema.Set).List()))
+		if err != nil {
+			return diag.FromErr(err)
 		}
-	})
-	if err != nil {
-		log.Printf("[CRITAL]%s create NAT gateway failed, reason:%s\n", logId, err.Error())
-		return err
 	}
-	return resourceTencentCloudNatGatewayRead(d, meta)
+
+	return resourceTencentCloudNatGatewayRead(ctx, d, meta)
 }
 
-func resourceTencentCloudNatGatewayRead(d *schema.ResourceData, meta interface{}) error {
+func resourceTencentCloudNatGatewayRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	defer logElapsed("resource.tencentcloud_nat_gateway.read")()
 	defer inconsistentCheck(d, meta)()
 
 	logId := getLogId(contextNil)
-	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+	ctx = context.WithValue(ctx, logIdKey, logId)
 
 	natGatewayId := d.Id()
 	request := vpc.NewDescribeNatGatewaysRequest()
 	request.NatGatewayIds = []*string{&natGatewayId}
 	var response *vpc.DescribeNatGatewaysResponse
-	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+	err := resource.RetryContext(ctx, readRetryTimeout, func() *resource.RetryError {
+		if err := ctx.Err(); err != nil {
+			return resource.NonRetryableError(err)
+		}
 		result, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().DescribeNatGateways(request)
 		if e != nil {
 			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
@@ -247,7 +461,7 @@ func resourceTencentCloudNatGatewayRead(d *schema.ResourceData, meta interface{}
 	})
 	if err != nil {
 		log.Printf("[CRITAL]%s read NAT gateway failed, reason:%s\n", logId, err.Error())
-		return err
+		return diag.FromErr(err)
 	}
 	if len(response.Response.NatGatewaySet) < 1 {
 		d.SetId("")
@@ -256,37 +470,60 @@ func resourceTencentCloudNatGatewayRead(d *schema.ResourceData, meta interface{}
 
 	nat := response.Response.NatGatewaySet[0]
 
-	_ = d.Set("vpc_id", *nat.VpcId)
-	_ = d.Set("name", *nat.NatGatewayName)
-	_ = d.Set("max_concurrent", *nat.MaxConcurrentConnection)
-	_ = d.Set("bandwidth", *nat.InternetMaxBandwidthOut)
-	_ = d.Set("created_time", *nat.CreatedTime)
-	_ = d.Set("assigned_eip_set", flattenAddressList((*nat).PublicIpAddressSet))
-	_ = d.Set("zone", *nat.Zone)
+	for k, v := range flattenNatGateway(nat) {
+		_ = d.Set(k, v)
+	}
+	// the EIPs allocated by the gateway are exported by allocated_eip_set only
+	if v, ok := d.GetOk("allocated_eip_set"); ok {
+		_ = d.Set("assigned_eip_set", natGatewayEipsExcept(nat, helper.InterfacesStrings(v.(*schema.Set).List())))
+	}
+
+	vpcService := VpcService{client: meta.(*TencentCloudClient).apiV3Conn}
+	eips, err := vpcService.DescribeNatGatewayEips(ctx, nat)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = d.Set("isp", natGatewayIsp(eips))
+	_ = d.Set("assigned_eip_detail", flattenNatGatewayEipDetail(nat.PublicIpAddressSet, eips))
+
+	err, snats := vpcService.DescribeNatGatewaySnats(ctx, d.Id(), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	snatSources := make([]map[string]interface{}, 0, len(snats))
+	for _, snat := range snats {
+		snatSources = append(snatSources, map[string]interface{}{
+			"snat_id":            snat.NatGatewaySnatId,
+			"resource_type":      snat.ResourceType,
+			"resource_id":        snat.ResourceId,
+			"private_ip_address": snat.PrivateIpAddress,
+		})
+	}
+	_ = d.Set("snat_sources", snatSources)
 
 	tcClient := meta.(*TencentCloudClient).apiV3Conn
 	tagService := &TagService{client: tcClient}
 	tags, err := tagService.DescribeResourceTags(ctx, "vpc", "nat", tcClient.Region, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	_ = d.Set("tags", tags)
 
 	return nil
 }
 
-func resourceTencentCloudNatGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceTencentCloudNatGatewayUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	defer logElapsed("resource.tencentcloud_nat_gateway.update")()
 
 	logId := getLogId(contextNil)
-	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+	ctx = context.WithValue(ctx, logIdKey, logId)
 	vpcService := VpcService{client: meta.(*TencentCloudClient).apiV3Conn}
 
 	immutableArgs := []string{"zone"}
 
 	for _, v := range immutableArgs {
 		if d.HasChange(v) {
-			return fmt.Errorf("argument `%s` cannot be changed", v)
+			return diag.Errorf("argument `%s` cannot be changed", v)
 		}
 	}
 
@@ -306,7 +543,11 @@ func resourceTencentCloudNatGatewayUpdate(d *schema.ResourceData, meta interface
 		changed = true
 	}
 	if changed {
-		err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		// returning while partial keeps the old name and bandwidth in state when the modification fails
+		err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
+			if err := ctx.Err(); err != nil {
+				return resource.NonRetryableError(err)
+			}
 			_, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().ModifyNatGatewayAttribute(request)
 			if e != nil {
 				log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
@@ -317,7 +558,14 @@ func resourceTencentCloudNatGatewayUpdate(d *schema.ResourceData, meta interface
 		})
 		if err != nil {
 			log.Printf("[CRITAL]%s modify NAT gateway failed, reason:%s\n", logId, err.Error())
-			return err
+			return diag.FromErr(err)
+		}
+	}
+	if d.HasChange("security_group_ids") {
+		securityGroupIds := helper.InterfacesStringsPoint(d.Get("security_group_ids").(*schema.Set).List())
+		err := vpcService.ModifyNatGatewaySecurityGroups(ctx, natGatewayId, securityGroupIds)
+		if err != nil {
+			return diag.FromErr(err)
 		}
 	}
 	//max concurrent
@@ -327,7 +575,10 @@ func resourceTencentCloudNatGatewayUpdate(d *schema.ResourceData, meta interface
 		concurrent := d.Get("max_concurrent").(int)
 		concurrent64 := uint64(concurrent)
 		concurrentReq.MaxConcurrentConnection = &concurrent64
-		err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
+			if err := ctx.Err(); err != nil {
+				return resource.NonRetryableError(err)
+			}
 			_, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().ResetNatGatewayConnection(concurrentReq)
 			if e != nil {
 				log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
@@ -338,143 +589,93 @@ func resourceTencentCloudNatGatewayUpdate(d *schema.ResourceData, meta interface
 		})
 		if err != nil {
 			log.Printf("[CRITAL]%s modify NAT gateway concurrent failed, reason:%s\n", logId, err.Error())
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	//eip
-
 	if d.HasChange("assigned_eip_set") {
-		eipSetLength := 0
-		if v, ok := d.GetOk("assigned_eip_set"); ok {
-			eipSet := v.(*schema.Set).List()
-			eipSetLength = len(eipSet)
-		}
-		if d.HasChange("assigned_eip_set") {
-			o, n := d.GetChange("assigned_eip_set")
-			os := o.(*schema.Set)
-			ns := n.(*schema.Set)
-			oldEipSet := os.List()
-			newEipSet := ns.List()
-
-			//in case of no union set
-			backUpOldIp := ""
-			backUpNewIp := ""
-			//Unassign eips
-			if len(oldEipSet) > 0 {
-				unassignedRequest := vpc.NewDisassociateNatGatewayAddressRequest()
-				unassignedRequest.PublicIpAddresses = make([]*string, 0, len(oldEipSet))
-				unassignedRequest.NatGatewayId = &natGatewayId
-				//set request public ips
-				for i := range oldEipSet {
-					publicIp := oldEipSet[i].(string)
-					isIn := false
-					for j := range newEipSet {
-						if publicIp == newEipSet[j] {
-							isIn = true
-						}
-					}
-					if !isIn {
-						if len(unassignedRequest.PublicIpAddresses)+1 == len(oldEipSet) {
-							backUpOldIp = publicIp
-						} else {
-							unassignedRequest.PublicIpAddresses = append(unassignedRequest.PublicIpAddresses, &publicIp)
-						}
-					}
-				}
+		o, n := d.GetChange("assigned_eip_set")
+		oldEipSet := helper.InterfacesStrings(o.(*schema.Set).List())
+		newEipSet := helper.InterfacesStrings(n.(*schema.Set).List())
+		if len(newEipSet) == 0 {
+			return diag.Errorf("assigned_eip_set of NAT gateway %s can not be emptied", natGatewayId)
+		}
 
-				if len(unassignedRequest.PublicIpAddresses) > 0 {
-					err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
-						e := vpcService.DisassociateNatGatewayAddress(ctx, unassignedRequest)
-						if e != nil {
-							return retryError(e)
-						}
-						return nil
-					})
-					if err != nil {
-						log.Printf("[CRITAL]%s modify NAT gateway EIP failed, reason:%s\n", logId, err.Error())
-						return err
-					}
-				}
+		// EIPs already bound to this gateway are not conflicts
+		conflicts, err := vpcService.CheckEipAvailable(ctx, newEipSet, natGatewayId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := eipConflictsError(conflicts); err != nil {
+			return diag.FromErr(err)
+		}
+
+		// the allocated EIPs stay bound, they count towards the limits of the gateway
+		for _, ip := range helper.InterfacesStrings(d.Get("allocated_eip_set").(*schema.Set).List()) {
+			if !IsContains(oldEipSet, ip) {
+				oldEipSet = append(oldEipSet, ip)
 			}
-			time.Sleep(3 * time.Minute)
-			//Assign new EIP
-			if len(newEipSet) > 0 {
-				assignedRequest := vpc.NewAssociateNatGatewayAddressRequest()
-				assignedRequest.PublicIpAddresses = make([]*string, 0, len(newEipSet))
-				assignedRequest.NatGatewayId = &natGatewayId
-				//set request public ips
-				for i := range newEipSet {
-					publicIp := newEipSet[i].(string)
-					isIn := false
-					for j := range oldEipSet {
-						if publicIp == oldEipSet[j] {
-							isIn = true
-						}
-					}
-					if !isIn {
-						if len(assignedRequest.PublicIpAddresses)+eipSetLength+1 == NAT_EIP_MAX_LIMIT {
-							backUpNewIp = publicIp
-						} else {
-							assignedRequest.PublicIpAddresses = append(assignedRequest.PublicIpAddresses, &publicIp)
-						}
-					}
-				}
-				if len(assignedRequest.PublicIpAddresses) > 0 {
-					err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
-						_, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().AssociateNatGatewayAddress(assignedRequest)
-						if e != nil {
-							log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
-								logId, assignedRequest.GetAction(), assignedRequest.ToJsonString(), e.Error())
-							return retryError(e)
-						}
-						return nil
-					})
-					if err != nil {
-						log.Printf("[CRITAL]%s modify NAT gateway EIP failed, reason:%s\n", logId, err.Error())
-						return err
-					}
-				}
+			if !IsContains(newEipSet, ip) {
+				newEipSet = append(newEipSet, ip)
 			}
-			time.Sleep(3 * time.Minute)
-			if backUpOldIp != "" {
-				//disassociate one old ip
-				unassignedRequest := vpc.NewDisassociateNatGatewayAddressRequest()
-				unassignedRequest.NatGatewayId = &natGatewayId
-				unassignedRequest.PublicIpAddresses = []*string{&backUpOldIp}
-				err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
-					e := vpcService.DisassociateNatGatewayAddress(ctx, unassignedRequest)
+		}
+
+		steps, err := planNatGatewayEipChanges(oldEipSet, newEipSet, NAT_EIP_MAX_LIMIT)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// EIPs expected on the gateway after each step, used to poll until the step settles
+		currentIps := oldEipSet
+		for _, step := range steps {
+			publicIps := helper.StringsStringsPoint(step.publicIps)
+			err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
+				if err := ctx.Err(); err != nil {
+					return resource.NonRetryableError(err)
+				}
+				if step.associate {
+					request := vpc.NewAssociateNatGatewayAddressRequest()
+					request.NatGatewayId = &natGatewayId
+					request.PublicIpAddresses = publicIps
+					_, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().AssociateNatGatewayAddress(request)
 					if e != nil {
+						log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+							logId, request.GetAction(), request.ToJsonString(), e.Error())
 						return retryError(e)
 					}
 					return nil
-				})
-				if err != nil {
-					log.Printf("[CRITAL]%s modify NAT gateway EIP failed, reason:%s\n", logId, err.Error())
-					return err
 				}
+
+				request := vpc.NewDisassociateNatGatewayAddressRequest()
+				request.NatGatewayId = &natGatewayId
+				request.PublicIpAddresses = publicIps
+				if e := vpcService.DisassociateNatGatewayAddress(ctx, request); e != nil {
+					return retryError(e)
+				}
+				return nil
+			})
+			if err != nil {
+				log.Printf("[CRITAL]%s modify NAT gateway EIP failed, reason:%s\n", logId, err.Error())
+				return diag.FromErr(err)
 			}
-			if backUpNewIp != "" {
-				//associate one new ip
-				assignedRequest := vpc.NewAssociateNatGatewayAddressRequest()
-				assignedRequest.NatGatewayId = &natGatewayId
-				assignedRequest.PublicIpAddresses = []*string{&backUpNewIp}
-				err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
-					_, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().AssociateNatGatewayAddress(assignedRequest)
-					if e != nil {
-						log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
-							logId, assignedRequest.GetAction(), assignedRequest.ToJsonString(), e.Error())
-						return retryError(e)
+
+			if step.associate {
+				currentIps = append(currentIps, step.publicIps...)
+			} else {
+				remainIps := make([]string, 0, len(currentIps))
+				for _, ip := range currentIps {
+					if !IsContains(step.publicIps, ip) {
+						remainIps = append(remainIps, ip)
 					}
-					return nil
-				})
-				if err != nil {
-					log.Printf("[CRITAL]%s modify NAT gateway EIP failed, reason:%s\n", logId, err.Error())
-					return err
 				}
+				currentIps = remainIps
+			}
+			if err := vpcService.WaitForNatGatewayEips(ctx, natGatewayId, currentIps, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				log.Printf("[CRITAL]%s modify NAT gateway EIP failed, reason:%s\n", logId, err.Error())
+				return diag.FromErr(err)
 			}
 		}
-
 	}
 
 	if d.HasChange("tags") {
@@ -487,24 +688,29 @@ func resourceTencentCloudNatGatewayUpdate(d *schema.ResourceData, meta interface
 		resourceName := BuildTagResourceName("vpc", "nat", tcClient.Region, d.Id())
 		err := tagService.ModifyTags(ctx, resourceName, replaceTags, deleteTags)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	d.Partial(false)
 
-	return nil
+	// read back so that the state follows the API, e.g. a name changed in the console meanwhile
+	return resourceTencentCloudNatGatewayRead(ctx, d, meta)
 }
 
-func resourceTencentCloudNatGatewayDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceTencentCloudNatGatewayDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	defer logElapsed("resource.tencentcloud_nat_gateway.delete")()
 
 	logId := getLogId(contextNil)
+	ctx = context.WithValue(ctx, logIdKey, logId)
 
 	natGatewayId := d.Id()
 	request := vpc.NewDeleteNatGatewayRequest()
 	request.NatGatewayId = &natGatewayId
-	err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		if err := ctx.Err(); err != nil {
+			return resource.NonRetryableError(err)
+		}
 		_, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().DeleteNatGateway(request)
 		if e != nil {
 			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
@@ -515,44 +721,237 @@ func resourceTencentCloudNatGatewayDelete(d *schema.ResourceData, meta interface
 	})
 	if err != nil {
 		log.Printf("[CRITAL]%s delete NAT gateway failed, reason:%s\n", logId, err.Error())
-		return err
+		return diag.FromErr(err)
 	}
 	// must wait for finishing deleting NAT
-	time.Sleep(10 * time.Second)
-	//to get the status of NAT
+	vpcService := VpcService{client: meta.(*TencentCloudClient).apiV3Conn}
+	err = helper.WaitForState(ctx, vpcService.NatGatewayStateFunc(ctx, natGatewayId),
+		[]string{NAT_NOT_FOUND_STATE}, []string{NAT_FAILED_STATE}, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		log.Printf("[CRITAL]%s delete NAT gateway failed, reason:%s\n", logId, err.Error())
+		return diag.FromErr(err)
+	}
 
-	statRequest := vpc.NewDescribeNatGatewaysRequest()
-	statRequest.NatGatewayIds = []*string{&natGatewayId}
-	err = resource.Retry(readRetryTimeout, func() *resource.RetryError {
-		result, e := meta.(*TencentCloudClient).apiV3Conn.UseVpcClient().DescribeNatGateways(statRequest)
-		if e != nil {
-			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
-				logId, request.GetAction(), request.ToJsonString(), e.Error())
-			return retryError(e)
-		} else {
-			//if not, quit
-			if len(result.Response.NatGatewaySet) == 0 {
-				log.Printf("deleting done")
-				return nil
-			}
-			//else get stat
-			nat := result.Response.NatGatewaySet[0]
-			stat := *nat.State
-			if stat == NAT_FAILED_STATE {
-				return resource.NonRetryableError(fmt.Errorf("delete NAT failed"))
-			}
-			time.Sleep(3 * time.Second)
+	if d.Get("release_eips_on_delete").(bool) {
+		if err := vpcService.ReleaseUnboundEipsByPublicIp(ctx, natGatewayReleasableEips(d)); err != nil {
+			log.Printf("[CRITAL]%s release EIPs of NAT gateway failed, reason:%s\n", logId, err.Error())
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
 
-			return resource.RetryableError(fmt.Errorf("deleting retry"))
+// resourceTencentCloudNatGatewayEipSetDiff refuses a plan which leaves the gateway without EIP,
+// or with more EIPs than allowed once the allocated ones are counted.
+func resourceTencentCloudNatGatewayEipSetDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("assigned_eip_set") {
+		return nil
+	}
+
+	assigned := 0
+	if v, ok := d.Get("assigned_eip_set").(*schema.Set); ok {
+		assigned = v.Len()
+	}
+	if assigned == 0 {
+		return fmt.Errorf("assigned_eip_set of NAT gateway can not be empty")
+	}
+
+	allocated := d.Get("eip_address_count").(int)
+	if d.Id() != "" {
+		allocated = d.Get("allocated_eip_set").(*schema.Set).Len()
+	}
+	if assigned+allocated > NAT_EIP_MAX_LIMIT {
+		return fmt.Errorf("NAT gateway can have at most %d EIPs, got %d assigned and %d allocated", NAT_EIP_MAX_LIMIT, assigned, allocated)
+	}
+	return nil
+}
+
+// natGatewayReleasableEips returns the EIPs which release_eips_on_delete releases. Only the EIPs allocated by the
+// gateway itself are returned, the EIPs of assigned_eip_set are brought in by the user and are never released.
+func natGatewayReleasableEips(d *schema.ResourceData) []string {
+	return helper.InterfacesStrings(d.Get("allocated_eip_set").(*schema.Set).List())
+}
+
+// natGatewayEipsExcept returns the EIPs of the NAT gateway which are not in excluded.
+func natGatewayEipsExcept(nat *vpc.NatGateway, excluded []string) []string {
+	eips := make([]string, 0)
+	for _, address := range nat.PublicIpAddressSet {
+		if address.PublicIpAddress != nil && !IsContains(excluded, *address.PublicIpAddress) {
+			eips = append(eips, *address.PublicIpAddress)
 		}
-	})
+	}
+	return eips
+}
+
+// natGatewayEipIds returns the IDs of the NAT gateway EIPs whose addresses are in publicIps.
+func natGatewayEipIds(nat *vpc.NatGateway, publicIps []string) []string {
+	ids := make([]string, 0, len(publicIps))
+	for _, address := range nat.PublicIpAddressSet {
+		if address.AddressId != nil && address.PublicIpAddress != nil && IsContains(publicIps, *address.PublicIpAddress) {
+			ids = append(ids, *address.AddressId)
+		}
+	}
+	return ids
+}
+
+// natGatewayEipStep is a single associate or disassociate call of NAT gateway EIPs.
+type natGatewayEipStep struct {
+	associate bool
+	publicIps []string
+}
+
+// planNatGatewayEipChanges computes the calls turning the EIPs of a NAT gateway from oldIps to newIps,
+// batching as many EIPs per call as possible while keeping the gateway between 1 and maxLimit EIPs.
+func planNatGatewayEipChanges(oldIps, newIps []string, maxLimit int) ([]natGatewayEipStep, error) {
+	if len(newIps) == 0 || len(newIps) > maxLimit {
+		return nil, fmt.Errorf("a NAT gateway must have between 1 and %d EIPs, got %d", maxLimit, len(newIps))
+	}
+
+	toAdd := make([]string, 0, len(newIps))
+	for _, ip := range newIps {
+		if !IsContains(oldIps, ip) {
+			toAdd = append(toAdd, ip)
+		}
+	}
+	toRemove := make([]string, 0, len(oldIps))
+	for _, ip := range oldIps {
+		if !IsContains(newIps, ip) {
+			toRemove = append(toRemove, ip)
+		}
+	}
+
+	steps := make([]natGatewayEipStep, 0)
+	current := len(oldIps)
+	for len(toAdd) > 0 || len(toRemove) > 0 {
+		progressed := false
+
+		// associate first, so that the old EIPs keep serving until the new ones are ready
+		count := len(toAdd)
+		if room := maxLimit - current; count > room {
+			count = room
+		}
+		if count > 0 {
+			steps = append(steps, natGatewayEipStep{associate: true, publicIps: toAdd[:count]})
+			toAdd = toAdd[count:]
+			current += count
+			progressed = true
+		}
+
+		count = len(toRemove)
+		if removable := current - 1; count > removable {
+			count = removable
+		}
+		if count > 0 {
+			steps = append(steps, natGatewayEipStep{associate: false, publicIps: toRemove[:count]})
+			toRemove = toRemove[count:]
+			current -= count
+			progressed = true
+		}
+
+		if !progressed {
+			return nil, fmt.Errorf("can not change NAT gateway EIPs from %v to %v", oldIps, newIps)
+		}
+	}
+	return steps, nil
+}
+
+// resourceTencentCloudNatGatewayBandwidthDiff refuses lowering the bandwidth below the recent peak usage when
+// bandwidth_usage_check is enabled. It is skipped when the flow monitor metrics are unavailable.
+func resourceTencentCloudNatGatewayBandwidthDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.Get("bandwidth_usage_check").(bool) || !d.HasChange("bandwidth") {
+		return nil
+	}
+
+	o, n := d.GetChange("bandwidth")
+	oldBandwidth, newBandwidth := o.(int), n.(int)
+	if newBandwidth >= oldBandwidth {
+		return nil
+	}
+
+	logId := getLogId(contextNil)
+	ctx = context.WithValue(ctx, logIdKey, logId)
+	vpcService := VpcService{client: meta.(*TencentCloudClient).apiV3Conn}
+
+	peak, err := vpcService.DescribeNatGatewayPeakOutBandwidth(ctx, d.Id(), NAT_BANDWIDTH_CHECK_MINUTES)
 	if err != nil {
-		log.Printf("[CRITAL]%s delete NAT gateway failed, reason:%s\n", logId, err.Error())
-		return err
+		log.Printf("[WARN]%s skip bandwidth usage check of NAT gateway [%s], reason:%s\n", logId, d.Id(), err.Error())
+		return nil
+	}
+
+	if float64(newBandwidth) < peak {
+		return fmt.Errorf("the new bandwidth %dMbps of NAT gateway %s is lower than its peak usage %.2fMbps in the last %d minutes, packets may be dropped. Set `bandwidth_usage_check = false` to lower it anyway",
+			newBandwidth, d.Id(), peak, NAT_BANDWIDTH_CHECK_MINUTES)
 	}
+
 	return nil
 }
 
+// flattenNatGateway returns the attributes shared by the NAT gateway resource and data sources,
+// attributes missing in the response are left out.
+func flattenNatGateway(nat *vpc.NatGateway) map[string]interface{} {
+	mapping := map[string]interface{}{
+		"assigned_eip_set":   flattenAddressList(nat.PublicIpAddressSet),
+		"security_group_ids": helper.StringsInterfaces(nat.SecurityGroupSet),
+	}
+	if nat.VpcId != nil {
+		mapping["vpc_id"] = *nat.VpcId
+	}
+	if nat.NatGatewayName != nil {
+		mapping["name"] = *nat.NatGatewayName
+	}
+	if nat.MaxConcurrentConnection != nil {
+		mapping["max_concurrent"] = int(*nat.MaxConcurrentConnection)
+	}
+	if nat.InternetMaxBandwidthOut != nil {
+		mapping["bandwidth"] = int(*nat.InternetMaxBandwidthOut)
+	}
+	if nat.CreatedTime != nil {
+		mapping["created_time"] = *nat.CreatedTime
+	}
+	if nat.Zone != nil {
+		mapping["zone"] = *nat.Zone
+	}
+	if nat.SubnetId != nil {
+		mapping["subnet_id"] = *nat.SubnetId
+	}
+	if nat.NatProductVersion != nil {
+		mapping["nat_product_version"] = int(*nat.NatProductVersion)
+	}
+	return mapping
+}
+
+// natGatewayStandardDiffSuppress ignores the bandwidth and concurrency settings of the standard NAT gateway,
+// which are not configurable for that product.
+func natGatewayStandardDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return d.Get("nat_product_version").(int) == NAT_PRODUCT_VERSION_STANDARD
+}
+
+// flattenNatGatewayEipDetail returns the EIPs of the NAT gateway in the order of the gateway,
+// the bandwidth is taken from the EIP details and left out when the EIP is not found.
+func flattenNatGatewayEipDetail(addresses []*vpc.NatGatewayAddress, eips []*vpc.Address) []map[string]interface{} {
+	bandwidths := make(map[string]uint64, len(eips))
+	for _, eip := range eips {
+		if eip.AddressIp != nil && eip.Bandwidth != nil {
+			bandwidths[*eip.AddressIp] = *eip.Bandwidth
+		}
+	}
+
+	details := make([]map[string]interface{}, 0, len(addresses))
+	for _, address := range addresses {
+		detail := map[string]interface{}{
+			"id":        address.AddressId,
+			"public_ip": address.PublicIpAddress,
+		}
+		if address.PublicIpAddress != nil {
+			if bandwidth, ok := bandwidths[*address.PublicIpAddress]; ok {
+				detail["bandwidth"] = int(bandwidth)
+			}
+		}
+		details = append(details, detail)
+	}
+	return details
+}
+
 func flattenAddressList(addresses []*vpc.NatGatewayAddress) (eips []*string) {
 	for _, address := range addresses {
 		eips = append(eips, address.PublicIpAddress)
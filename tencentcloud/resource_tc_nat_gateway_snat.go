@@ -272,23 +272,31 @@ func resourceTencentCloudNatGatewaySnatRead(d *schema.ResourceData, meta interfa
 		service = VpcService{client: meta.(*TencentCloudClient).apiV3Conn}
 		id      = d.Id()
 	)
-	compositeId := helper.IdParse(id)
-	if len(compositeId) != 2 {
-		return errors.New("the id format must be '{nat_gateway_id}#{resource_id}'")
+	compositeId, err := helper.ParseCompositeId(id, 2, "nat_gateway_id", "resource_id")
+	if err != nil {
+		return err
 	}
 
 	err, snatList := service.DescribeNatGatewaySnats(ctx, compositeId[0], nil)
 	if err != nil {
+		// the rules are gone with the NAT gateway
+		if isExpectError(err, []string{"ResourceNotFound"}) {
+			log.Printf("[WARN]%s nat gateway [%s] of snat [%s] not found, remove it from state\n", logId, compositeId[0], id)
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[CRITAL]%s read nat gateway snat failed, reason:%s\n", logId, err.Error())
 		return err
 	}
 	var snat *vpc.SourceIpTranslationNatRule
 	for _, s := range snatList {
-		if compositeId[1] == *s.ResourceId {
+		if s.ResourceId != nil && compositeId[1] == *s.ResourceId {
 			snat = s
+			break
 		}
 	}
 	if snat == nil {
+		log.Printf("[WARN]%s nat gateway snat [%s] not found, its subnet or instance may be deleted, remove it from state\n", logId, id)
 		d.SetId("")
 		return nil
 	}
@@ -35,6 +35,11 @@ func TestAccTencentCloudNatGatewaySnat_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("tencentcloud_nat_gateway_snat.my_subnet_snat", "public_ip_addr.#", "1"),
 				),
 			},
+			{
+				ResourceName:      "tencentcloud_nat_gateway_snat.my_subnet_snat",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"regexp"
+	"sort"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 )
 
 func init() {
@@ -81,6 +85,10 @@ func TestAccTencentCloudNatGateway_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("tencentcloud_nat_gateway.my_nat", "bandwidth", "500"),
 					resource.TestCheckResourceAttr("tencentcloud_nat_gateway.my_nat", "assigned_eip_set.#", "2"),
 					resource.TestCheckResourceAttr("tencentcloud_nat_gateway.my_nat", "tags.tf", "test"),
+					resource.TestCheckResourceAttr("tencentcloud_nat_gateway.my_nat", "isp", "BGP"),
+					resource.TestCheckResourceAttr("tencentcloud_nat_gateway.my_nat", "snat_sources.#", "0"),
+					resource.TestCheckResourceAttr("tencentcloud_nat_gateway.my_nat", "assigned_eip_detail.#", "2"),
+					resource.TestCheckResourceAttrSet("tencentcloud_nat_gateway.my_nat", "assigned_eip_detail.0.bandwidth"),
 				),
 			},
 			{
@@ -98,6 +106,317 @@ func TestAccTencentCloudNatGateway_basic(t *testing.T) {
 	})
 }
 
+func TestAccTencentCloudNatGateway_allocatedEips(t *testing.T) {
+	t.Parallel()
+	var allocatedEips []string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNatGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNatGatewayConfigAllocatedEips,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNatGatewayExists("tencentcloud_nat_gateway.my_nat"),
+					testAccSaveNatGatewayEips("tencentcloud_nat_gateway.my_nat", "allocated_eip_set", &allocatedEips),
+					resource.TestCheckResourceAttr("tencentcloud_nat_gateway.my_nat", "assigned_eip_set.#", "1"),
+					resource.TestCheckResourceAttr("tencentcloud_nat_gateway.my_nat", "allocated_eip_set.#", "1"),
+					resource.TestCheckResourceAttr("tencentcloud_nat_gateway.my_nat", "assigned_eip_detail.#", "2"),
+					testAccCheckNatGatewayEipTags("tencentcloud_nat_gateway.my_nat", "allocated_eip_set", map[string]string{"tf": "test"}),
+					testAccCheckNatGatewayEipTags("tencentcloud_nat_gateway.my_nat", "assigned_eip_set", map[string]string{}),
+				),
+			},
+			{
+				// destroying the gateway releases the allocated EIPs only, the user-supplied EIP survives
+				Config: testAccNatGatewayConfigAllocatedEipsDestroyed,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEipExists("tencentcloud_eip.eip_dev_dnat"),
+					testAccCheckNatGatewayEipsReleased(&allocatedEips),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTencentCloudNatGateway_renameFailed(t *testing.T) {
+	t.Parallel()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNatGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNatGatewayConfigRename("terraform_test", 100),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNatGatewayExists("tencentcloud_nat_gateway.my_nat"),
+					resource.TestCheckResourceAttr("tencentcloud_nat_gateway.my_nat", "name", "terraform_test"),
+				),
+			},
+			{
+				// the invalid bandwidth fails ModifyNatGatewayAttribute together with the rename
+				Config:      testAccNatGatewayConfigRename("terraform_test_rename", 30),
+				ExpectError: regexp.MustCompile(`ModifyNatGatewayAttribute`),
+			},
+			{
+				Config:   testAccNatGatewayConfigRename("terraform_test", 100),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccNatGatewayConfigRename("terraform_test_rename", 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_nat_gateway.my_nat", "name", "terraform_test_rename"),
+					resource.TestCheckResourceAttr("tencentcloud_nat_gateway.my_nat", "bandwidth", "100"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenNatGateway(t *testing.T) {
+	nat := &vpc.NatGateway{
+		NatGatewayId:            helper.String("nat-xxxxxxxx"),
+		VpcId:                   helper.String("vpc-xxxxxxxx"),
+		NatGatewayName:          helper.String("terraform_test"),
+		MaxConcurrentConnection: helper.Uint64(1000000),
+		InternetMaxBandwidthOut: helper.Uint64(100),
+		CreatedTime:             helper.String("2023-01-01 00:00:00"),
+		Zone:                    helper.String("ap-guangzhou-3"),
+		PublicIpAddressSet:      []*vpc.NatGatewayAddress{{PublicIpAddress: helper.String("1.1.1.1")}},
+		SecurityGroupSet:        []*string{helper.String("sg-xxxxxxxx")},
+		SubnetId:                helper.String("subnet-xxxxxxxx"),
+		NatProductVersion:       helper.Uint64(NAT_PRODUCT_VERSION_STANDARD),
+	}
+
+	mapping := flattenNatGateway(nat)
+	expected := map[string]interface{}{
+		"vpc_id":              "vpc-xxxxxxxx",
+		"name":                "terraform_test",
+		"max_concurrent":      1000000,
+		"bandwidth":           100,
+		"created_time":        "2023-01-01 00:00:00",
+		"zone":                "ap-guangzhou-3",
+		"subnet_id":           "subnet-xxxxxxxx",
+		"nat_product_version": NAT_PRODUCT_VERSION_STANDARD,
+	}
+	for k, v := range expected {
+		if mapping[k] != v {
+			t.Errorf("%s: expected %v, got %v", k, v, mapping[k])
+		}
+	}
+	if eips := mapping["assigned_eip_set"].([]*string); len(eips) != 1 || *eips[0] != "1.1.1.1" {
+		t.Errorf("assigned_eip_set: unexpected %v", eips)
+	}
+	if sgs := mapping["security_group_ids"].([]interface{}); len(sgs) != 1 || sgs[0] != "sg-xxxxxxxx" {
+		t.Errorf("security_group_ids: unexpected %v", sgs)
+	}
+
+	mapping = flattenNatGateway(&vpc.NatGateway{})
+	for _, k := range []string{"vpc_id", "name", "max_concurrent", "bandwidth", "created_time", "zone", "subnet_id", "nat_product_version"} {
+		if _, ok := mapping[k]; ok {
+			t.Errorf("%s: expected to be left out when missing", k)
+		}
+	}
+}
+
+func TestFlattenNatGatewayEipDetail(t *testing.T) {
+	addresses := []*vpc.NatGatewayAddress{
+		{AddressId: helper.String("eip-aaaaaaaa"), PublicIpAddress: helper.String("1.1.1.1")},
+		{AddressId: helper.String("eip-bbbbbbbb"), PublicIpAddress: helper.String("2.2.2.2")},
+	}
+	eips := []*vpc.Address{
+		{AddressId: helper.String("eip-bbbbbbbb"), AddressIp: helper.String("2.2.2.2"), Bandwidth: helper.Uint64(50)},
+		{AddressId: helper.String("eip-aaaaaaaa"), AddressIp: helper.String("1.1.1.1"), Bandwidth: helper.Uint64(100)},
+	}
+
+	details := flattenNatGatewayEipDetail(addresses, eips)
+	if len(details) != 2 {
+		t.Fatalf("expected 2 details, got %d", len(details))
+	}
+	if *details[0]["id"].(*string) != "eip-aaaaaaaa" || *details[0]["public_ip"].(*string) != "1.1.1.1" || details[0]["bandwidth"] != 100 {
+		t.Errorf("unexpected detail %v", details[0])
+	}
+	if *details[1]["id"].(*string) != "eip-bbbbbbbb" || details[1]["bandwidth"] != 50 {
+		t.Errorf("unexpected detail %v", details[1])
+	}
+
+	details = flattenNatGatewayEipDetail(addresses, nil)
+	if _, ok := details[0]["bandwidth"]; ok {
+		t.Errorf("bandwidth: expected to be left out when the EIP is not found")
+	}
+}
+
+func TestEipConflictsError(t *testing.T) {
+	if err := eipConflictsError(map[string]string{}); err != nil {
+		t.Fatalf("expected no error without conflicts, got %v", err)
+	}
+
+	err := eipConflictsError(map[string]string{
+		"2.2.2.2": "it is bound to ins-xxxxxxxx",
+		"1.1.1.1": "its status is BINDING",
+	})
+	expected := "EIP 1.1.1.1 can not be bound, its status is BINDING; EIP 2.2.2.2 can not be bound, it is bound to ins-xxxxxxxx"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %v", expected, err)
+	}
+}
+
+func TestPlanNatGatewayEipChanges(t *testing.T) {
+	ips := func(prefix string, count int) []string {
+		result := make([]string, 0, count)
+		for i := 1; i <= count; i++ {
+			result = append(result, fmt.Sprintf("%s.%d", prefix, i))
+		}
+		return result
+	}
+
+	cases := []struct {
+		name     string
+		oldIps   []string
+		newIps   []string
+		maxSteps int
+	}{
+		{"swap all", ips("1.1.1", 3), ips("2.2.2", 3), 2},
+		{"swap all at limit", ips("1.1.1", NAT_EIP_MAX_LIMIT), ips("2.2.2", NAT_EIP_MAX_LIMIT), 4},
+		{"swap the only one", ips("1.1.1", 1), ips("2.2.2", 1), 2},
+		{"keep one", ips("1.1.1", 3), ips("1.1.1", 1), 1},
+		{"unchanged", ips("1.1.1", 2), ips("1.1.1", 2), 0},
+	}
+	for _, c := range cases {
+		steps, err := planNatGatewayEipChanges(c.oldIps, c.newIps, NAT_EIP_MAX_LIMIT)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", c.name, err)
+		}
+		if len(steps) > c.maxSteps {
+			t.Errorf("%s: expected at most %d steps, got %d", c.name, c.maxSteps, len(steps))
+		}
+
+		current := append([]string{}, c.oldIps...)
+		for _, step := range steps {
+			if step.associate {
+				current = append(current, step.publicIps...)
+			} else {
+				remain := make([]string, 0, len(current))
+				for _, ip := range current {
+					if !IsContains(step.publicIps, ip) {
+						remain = append(remain, ip)
+					}
+				}
+				current = remain
+			}
+			if len(current) < 1 || len(current) > NAT_EIP_MAX_LIMIT {
+				t.Fatalf("%s: gateway holds %d EIPs after a step", c.name, len(current))
+			}
+		}
+
+		sort.Strings(current)
+		expected := append([]string{}, c.newIps...)
+		sort.Strings(expected)
+		if strings.Join(current, ",") != strings.Join(expected, ",") {
+			t.Errorf("%s: expected EIPs %v, got %v", c.name, expected, current)
+		}
+	}
+
+	if _, err := planNatGatewayEipChanges(ips("1.1.1", 1), ips("2.2.2", NAT_EIP_MAX_LIMIT+1), NAT_EIP_MAX_LIMIT); err == nil {
+		t.Errorf("expected an error when exceeding %d EIPs", NAT_EIP_MAX_LIMIT)
+	}
+}
+
+func TestNatGatewayReleasableEips(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "nat-xxxxxxxx",
+		Attributes: map[string]string{
+			"id":                     "nat-xxxxxxxx",
+			"release_eips_on_delete": "true",
+			"assigned_eip_set.#":     "1",
+			"assigned_eip_set.0":     "1.1.1.1",
+			"allocated_eip_set.#":    "2",
+			"allocated_eip_set.0":    "2.2.2.2",
+			"allocated_eip_set.1":    "3.3.3.3",
+		},
+	}
+	d, err := schema.InternalMap(resourceTencentCloudNatGateway().Schema).Data(state, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	released := natGatewayReleasableEips(d)
+	sort.Strings(released)
+	if strings.Join(released, ",") != "2.2.2.2,3.3.3.3" {
+		t.Errorf("expected only the allocated EIPs 2.2.2.2,3.3.3.3 to be released, got %v", released)
+	}
+	if IsContains(released, "1.1.1.1") {
+		t.Errorf("expected the user-supplied EIP 1.1.1.1 to survive the destroy")
+	}
+}
+
+func TestNatGatewayEipsExcept(t *testing.T) {
+	nat := &vpc.NatGateway{
+		PublicIpAddressSet: []*vpc.NatGatewayAddress{
+			{PublicIpAddress: helper.String("1.1.1.1")},
+			{PublicIpAddress: helper.String("2.2.2.2")},
+			{PublicIpAddress: helper.String("3.3.3.3")},
+		},
+	}
+
+	// EIPs brought in by assigned_eip_set are never treated as allocated
+	allocated := natGatewayEipsExcept(nat, []string{"1.1.1.1"})
+	if strings.Join(allocated, ",") != "2.2.2.2,3.3.3.3" {
+		t.Errorf("expected the allocated EIPs 2.2.2.2,3.3.3.3, got %v", allocated)
+	}
+	if allocated := natGatewayEipsExcept(nat, []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"}); len(allocated) != 0 {
+		t.Errorf("expected no allocated EIPs, got %v", allocated)
+	}
+}
+
+func TestNatGatewayEipIds(t *testing.T) {
+	nat := &vpc.NatGateway{
+		PublicIpAddressSet: []*vpc.NatGatewayAddress{
+			{AddressId: helper.String("eip-1"), PublicIpAddress: helper.String("1.1.1.1")},
+			{AddressId: helper.String("eip-2"), PublicIpAddress: helper.String("2.2.2.2")},
+			{PublicIpAddress: helper.String("3.3.3.3")},
+		},
+	}
+
+	// only the allocated EIPs are tagged, the one brought in by assigned_eip_set is left out
+	ids := natGatewayEipIds(nat, []string{"2.2.2.2", "3.3.3.3"})
+	if strings.Join(ids, ",") != "eip-2" {
+		t.Errorf("expected the EIP ids eip-2, got %v", ids)
+	}
+	if ids := natGatewayEipIds(nat, nil); len(ids) != 0 {
+		t.Errorf("expected no EIP ids, got %v", ids)
+	}
+}
+
+func TestNatGatewayBandwidthDiffDisabled(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "nat-xxxxxxxx",
+		Attributes: map[string]string{
+			"id":                 "nat-xxxxxxxx",
+			"vpc_id":             "vpc-xxxxxxxx",
+			"name":               "terraform_test",
+			"bandwidth":          "500",
+			"max_concurrent":     "1000000",
+			"assigned_eip_set.#": "1",
+			"assigned_eip_set.0": "1.1.1.1",
+		},
+	}
+	raw := map[string]interface{}{
+		"vpc_id":           "vpc-xxxxxxxx",
+		"name":             "terraform_test",
+		"bandwidth":        100,
+		"assigned_eip_set": []interface{}{"1.1.1.1"},
+	}
+
+	// without bandwidth_usage_check the flow monitor is never queried, the nil meta would panic otherwise
+	diff, err := resourceTencentCloudNatGateway().Diff(context.TODO(), state, terraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("expected lowering the bandwidth to be planned, got %v", err)
+	}
+	if diff == nil || diff.Attributes["bandwidth"] == nil || diff.Attributes["bandwidth"].New != "100" {
+		t.Fatalf("expected a diff on bandwidth, got %v", diff)
+	}
+}
+
 func testAccCheckNatGatewayDestroy(s *terraform.State) error {
 	logId := getLogId(contextNil)
 
@@ -131,6 +450,92 @@ func testAccCheckNatGatewayDestroy(s *terraform.State) error {
 	return nil
 }
 
+// testAccSaveNatGatewayEips saves the EIPs of the set attribute of the NAT gateway, so they can be checked after the
+// gateway is destroyed.
+func testAccSaveNatGatewayEips(n, attr string, publicIps *[]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("nat gateway instance %s is not found", n)
+		}
+		*publicIps = (*publicIps)[:0]
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasPrefix(k, attr+".") && k != attr+".#" {
+				*publicIps = append(*publicIps, v)
+			}
+		}
+		if len(*publicIps) == 0 {
+			return fmt.Errorf("nat gateway %s has no EIP in %s", n, attr)
+		}
+		return nil
+	}
+}
+
+// testAccCheckNatGatewayEipsReleased checks that none of the public IPs is an EIP of the account any more.
+func testAccCheckNatGatewayEipsReleased(publicIps *[]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		logId := getLogId(contextNil)
+		ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+		vpcService := VpcService{client: testAccProvider.Meta().(*TencentCloudClient).apiV3Conn}
+		eips, err := vpcService.DescribeEipByFilter(ctx, map[string][]string{"address-ip": *publicIps})
+		if err != nil {
+			return err
+		}
+		for _, eip := range eips {
+			if eip.AddressIp != nil && IsContains(*publicIps, *eip.AddressIp) {
+				return fmt.Errorf("allocated EIP %s is not released", *eip.AddressIp)
+			}
+		}
+		return nil
+	}
+}
+
+// testAccCheckNatGatewayEipTags checks the tags of every EIP in the set attribute of the NAT gateway.
+func testAccCheckNatGatewayEipTags(n, attr string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		logId := getLogId(contextNil)
+		ctx := context.WithValue(context.TODO(), logIdKey, logId)
+
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("nat gateway instance %s is not found", n)
+		}
+		publicIps := make([]string, 0)
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasPrefix(k, attr+".") && k != attr+".#" {
+				publicIps = append(publicIps, v)
+			}
+		}
+
+		tcClient := testAccProvider.Meta().(*TencentCloudClient).apiV3Conn
+		vpcService := VpcService{client: tcClient}
+		tagService := TagService{client: tcClient}
+		eips, err := vpcService.DescribeEipByFilter(ctx, map[string][]string{"address-ip": publicIps})
+		if err != nil {
+			return err
+		}
+		if len(eips) != len(publicIps) {
+			return fmt.Errorf("expected %d EIPs of %s, got %d", len(publicIps), attr, len(eips))
+		}
+		for _, eip := range eips {
+			tags, err := tagService.DescribeResourceTags(ctx, VPC_SERVICE_TYPE, EIP_RESOURCE_TYPE, tcClient.Region, *eip.AddressId)
+			if err != nil {
+				return err
+			}
+			if len(tags) != len(expected) {
+				return fmt.Errorf("expected tags %v of EIP %s, got %v", expected, *eip.AddressId, tags)
+			}
+			for k, v := range expected {
+				if tags[k] != v {
+					return fmt.Errorf("expected tags %v of EIP %s, got %v", expected, *eip.AddressId, tags)
+				}
+			}
+		}
+		return nil
+	}
+}
+
 func testAccCheckNatGatewayExists(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		logId := getLogId(contextNil)
@@ -220,3 +625,56 @@ resource "tencentcloud_nat_gateway" "my_nat" {
   }
 }
 `
+
+const testAccNatGatewayConfigAllocatedEips = `
+data "tencentcloud_vpc_instances" "foo" {
+  name = "Default-VPC"
+}
+
+resource "tencentcloud_eip" "eip_dev_dnat" {
+  name = "terraform_test"
+}
+
+resource "tencentcloud_nat_gateway" "my_nat" {
+  vpc_id                 = data.tencentcloud_vpc_instances.foo.instance_list.0.vpc_id
+  name                   = "terraform_test_allocated_eips"
+  eip_address_count      = 1
+  release_eips_on_delete = true
+
+  assigned_eip_set = [
+    tencentcloud_eip.eip_dev_dnat.public_ip,
+  ]
+  tags = {
+    tf = "test"
+  }
+}
+`
+
+const testAccNatGatewayConfigAllocatedEipsDestroyed = `
+resource "tencentcloud_eip" "eip_dev_dnat" {
+  name = "terraform_test"
+}
+`
+
+func testAccNatGatewayConfigRename(name string, bandwidth int) string {
+	return fmt.Sprintf(`
+data "tencentcloud_vpc_instances" "foo" {
+  name = "Default-VPC"
+}
+
+resource "tencentcloud_eip" "eip_dev_dnat" {
+  name = "terraform_test"
+}
+
+resource "tencentcloud_nat_gateway" "my_nat" {
+  vpc_id         = data.tencentcloud_vpc_instances.foo.instance_list.0.vpc_id
+  name           = "%s"
+  max_concurrent = 1000000
+  bandwidth      = %d
+
+  assigned_eip_set = [
+    tencentcloud_eip.eip_dev_dnat.public_ip,
+  ]
+}
+`, name, bandwidth)
+}
@@ -1,6 +1,8 @@
 /*
 Provides a resource to create a scf function_event_invoke_config
 
+~> **NOTE:** Deleting the resource restores the default async trigger config of the function, which retries 2 times and keeps messages for 21600 seconds.
+
 Example Usage
 
 ```hcl
@@ -11,7 +13,7 @@ resource "tencentcloud_scf_function_event_invoke_config" "function_event_invoke_
     retry_config {
       retry_num = 2
     }
-    msg_ttl = 24
+    msg_ttl = 180
   }
 }
 ```
@@ -23,6 +25,8 @@ scf function_event_invoke_config can be imported using the id, e.g.
 ```
 terraform import tencentcloud_scf_function_event_invoke_config.function_event_invoke_config function_name#namespace
 ```
+
+The namespace can be left empty for the `default` namespace, e.g. `function_name#`.
 */
 package tencentcloud
 
@@ -30,7 +34,8 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
+	"os"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -45,8 +50,10 @@ func resourceTencentCloudScfFunctionEventInvokeConfig() *schema.Resource {
 		Update: resourceTencentCloudScfFunctionEventInvokeConfigUpdate,
 		Delete: resourceTencentCloudScfFunctionEventInvokeConfigDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceTencentCloudScfFunctionEventInvokeConfigImport,
 		},
+		CustomizeDiff: resourceTencentCloudScfFunctionEventInvokeConfigMsgTtlDiff,
+
 		Schema: map[string]*schema.Schema{
 			"function_name": {
 				Required:    true,
@@ -84,11 +91,24 @@ func resourceTencentCloudScfFunctionEventInvokeConfig() *schema.Resource {
 						"msg_ttl": {
 							Type:        schema.TypeInt,
 							Required:    true,
-							Description: "Message retention period.",
+							Description: "Message retention period, in seconds.",
 						},
 					},
 				},
 			},
+
+			"msg_ttl_check": {
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Default:     false,
+				Description: "Whether to fail the plan when `msg_ttl` leaves less than 60 seconds for each retry of any `retry_config`, the threshold can be tuned by env `TENCENTCLOUD_SCF_ASYNC_RETRY_TTL_PER_RETRY`. Default is `false`.",
+			},
+
+			"function_id": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "ID of the function the config is applied to. When the function is recreated with the same name, the ID changes and the config is applied again.",
+			},
 		},
 	}
 }
@@ -100,11 +120,40 @@ func resourceTencentCloudScfFunctionEventInvokeConfigCreate(d *schema.ResourceDa
 	functionName := d.Get("function_name").(string)
 	namespace := d.Get("namespace").(string)
 
+	// fail before the id is set, otherwise a malformed config leaves a tainted resource behind
+	if _, err := buildScfAsyncTriggerConfig(d); err != nil {
+		return err
+	}
+
 	d.SetId(functionName + FILED_SP + namespace)
 
 	return resourceTencentCloudScfFunctionEventInvokeConfigUpdate(d, meta)
 }
 
+func resourceTencentCloudScfFunctionEventInvokeConfigImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	functionName, namespace, err := parseScfFunctionEventInvokeConfigImportId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(helper.IdFormat(functionName, namespace))
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseScfFunctionEventInvokeConfigImportId parses `function_name#namespace`, an empty namespace means `default`.
+func parseScfFunctionEventInvokeConfigImportId(id string) (functionName, namespace string, err error) {
+	idSplit := helper.IdParse(id)
+	if len(idSplit) != 2 || idSplit[0] == "" {
+		err = fmt.Errorf("id `%s` is broken, expected format is `functionName#namespace`", id)
+		return
+	}
+	functionName = idSplit[0]
+	namespace = idSplit[1]
+	if namespace == "" {
+		namespace = "default"
+	}
+	return
+}
+
 func resourceTencentCloudScfFunctionEventInvokeConfigRead(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_scf_function_event_invoke_config.read")()
 	defer inconsistentCheck(d, meta)()
@@ -115,9 +164,9 @@ func resourceTencentCloudScfFunctionEventInvokeConfigRead(d *schema.ResourceData
 
 	service := ScfService{client: meta.(*TencentCloudClient).apiV3Conn}
 
-	idSplit := strings.Split(d.Id(), FILED_SP)
-	if len(idSplit) != 2 {
-		return fmt.Errorf("id is broken,%s", d.Id())
+	idSplit, err := helper.ParseCompositeId(d.Id(), 2, "functionName", "namespace")
+	if err != nil {
+		return err
 	}
 	functionName := idSplit[0]
 	namespace := idSplit[1]
@@ -133,30 +182,21 @@ func resourceTencentCloudScfFunctionEventInvokeConfigRead(d *schema.ResourceData
 		return nil
 	}
 
-	if FunctionEventInvokeConfig != nil {
-		asyncTriggerConfigMap := map[string]interface{}{}
-
-		if FunctionEventInvokeConfig.RetryConfig != nil {
-			retryConfigList := []interface{}{}
-			for _, retryConfig := range FunctionEventInvokeConfig.RetryConfig {
-				retryConfigMap := map[string]interface{}{}
-
-				if retryConfig.RetryNum != nil {
-					retryConfigMap["retry_num"] = retryConfig.RetryNum
-				}
-
-				retryConfigList = append(retryConfigList, retryConfigMap)
-			}
-
-			asyncTriggerConfigMap["retry_config"] = retryConfigList
-		}
-
-		if FunctionEventInvokeConfig.MsgTTL != nil {
-			asyncTriggerConfigMap["msg_ttl"] = FunctionEventInvokeConfig.MsgTTL
-		}
-
-		_ = d.Set("async_trigger_config", []interface{}{asyncTriggerConfigMap})
+	functionId, err := describeScfFunctionId(ctx, &service, functionName, namespace)
+	if err != nil {
+		return err
+	}
+	if scfFunctionRecreated(d.Get("function_id").(string), functionId) {
+		// the config read back belongs to another function, drop it so the next apply sets it again
+		log.Printf("[WARN]%s function [%s] of resource `ScfFunctionEventInvokeConfig` has been recreated, the config will be applied again.\n", logId, d.Id())
+		_ = d.Set("async_trigger_config", []interface{}{})
+		return nil
 	}
+	if functionId != "" {
+		_ = d.Set("function_id", functionId)
+	}
+
+	_ = d.Set("async_trigger_config", []interface{}{flattenScfAsyncTriggerConfig(FunctionEventInvokeConfig)})
 
 	_ = d.Set("function_name", functionName)
 
@@ -173,9 +213,9 @@ func resourceTencentCloudScfFunctionEventInvokeConfigUpdate(d *schema.ResourceDa
 
 	request := scf.NewUpdateFunctionEventInvokeConfigRequest()
 
-	idSplit := strings.Split(d.Id(), FILED_SP)
-	if len(idSplit) != 2 {
-		return fmt.Errorf("id is broken,%s", d.Id())
+	idSplit, err := helper.ParseCompositeId(d.Id(), 2, "functionName", "namespace")
+	if err != nil {
+		return err
 	}
 	functionName := idSplit[0]
 	namespace := idSplit[1]
@@ -183,25 +223,13 @@ func resourceTencentCloudScfFunctionEventInvokeConfigUpdate(d *schema.ResourceDa
 	request.Namespace = &namespace
 	request.FunctionName = &functionName
 
-	if dMap, ok := helper.InterfacesHeadMap(d, "async_trigger_config"); ok {
-		asyncTriggerConfig := scf.AsyncTriggerConfig{}
-		if v, ok := dMap["retry_config"]; ok {
-			for _, item := range v.([]interface{}) {
-				retryConfigMap := item.(map[string]interface{})
-				retryConfig := scf.RetryConfig{}
-				if v, ok := retryConfigMap["retry_num"]; ok {
-					retryConfig.RetryNum = helper.IntInt64(v.(int))
-				}
-				asyncTriggerConfig.RetryConfig = append(asyncTriggerConfig.RetryConfig, &retryConfig)
-			}
-		}
-		if v, ok := dMap["msg_ttl"]; ok {
-			asyncTriggerConfig.MsgTTL = helper.IntInt64(v.(int))
-		}
-		request.AsyncTriggerConfig = &asyncTriggerConfig
+	asyncTriggerConfig, err := buildScfAsyncTriggerConfig(d)
+	if err != nil {
+		return err
 	}
+	request.AsyncTriggerConfig = asyncTriggerConfig
 
-	err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+	err = resource.Retry(writeRetryTimeout, func() *resource.RetryError {
 		result, e := meta.(*TencentCloudClient).apiV3Conn.UseScfClient().UpdateFunctionEventInvokeConfig(request)
 		if e != nil {
 			return retryError(e)
@@ -215,12 +243,179 @@ func resourceTencentCloudScfFunctionEventInvokeConfigUpdate(d *schema.ResourceDa
 		return err
 	}
 
+	// bind the state to the function just configured, so Read does not take it as recreated
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+	service := ScfService{client: meta.(*TencentCloudClient).apiV3Conn}
+	functionId, err := describeScfFunctionId(ctx, &service, functionName, namespace)
+	if err != nil {
+		return err
+	}
+	_ = d.Set("function_id", functionId)
+
 	return resourceTencentCloudScfFunctionEventInvokeConfigRead(d, meta)
 }
 
+// flattenScfAsyncTriggerConfig maps the config to `async_trigger_config` of the resource and the data source.
+func flattenScfAsyncTriggerConfig(config *scf.AsyncTriggerConfig) map[string]interface{} {
+	asyncTriggerConfigMap := map[string]interface{}{}
+
+	if config.RetryConfig != nil {
+		retryConfigList := []interface{}{}
+		for _, retryConfig := range config.RetryConfig {
+			retryConfigMap := map[string]interface{}{}
+
+			if retryConfig.RetryNum != nil {
+				retryConfigMap["retry_num"] = retryConfig.RetryNum
+			}
+
+			retryConfigList = append(retryConfigList, retryConfigMap)
+		}
+
+		asyncTriggerConfigMap["retry_config"] = retryConfigList
+	}
+
+	if config.MsgTTL != nil {
+		asyncTriggerConfigMap["msg_ttl"] = config.MsgTTL
+	}
+
+	return asyncTriggerConfigMap
+}
+
+// describeScfFunctionId returns the FunctionId of the function, or empty if it can not be got.
+func describeScfFunctionId(ctx context.Context, service *ScfService, functionName, namespace string) (string, error) {
+	resp, err := service.DescribeFunction(ctx, functionName, namespace)
+	if err != nil {
+		return "", err
+	}
+	if resp == nil || resp.Response == nil {
+		return "", nil
+	}
+	return helper.PString(resp.Response.FunctionId), nil
+}
+
+// scfFunctionRecreated reports whether the function id has changed, functions without an id are never taken as recreated.
+func scfFunctionRecreated(oldId, newId string) bool {
+	return oldId != "" && newId != "" && oldId != newId
+}
+
+// buildScfAsyncTriggerConfig builds the async trigger config from `async_trigger_config`,
+// UpdateFunctionEventInvokeConfig would silently keep the old config if it is sent incomplete.
+func buildScfAsyncTriggerConfig(d *schema.ResourceData) (*scf.AsyncTriggerConfig, error) {
+	dMap, ok := helper.InterfacesHeadMap(d, "async_trigger_config")
+	if !ok {
+		return nil, fmt.Errorf("`async_trigger_config` is required")
+	}
+
+	asyncTriggerConfig := scf.AsyncTriggerConfig{}
+	retryConfigs, _ := dMap["retry_config"].([]interface{})
+	if len(retryConfigs) == 0 {
+		return nil, fmt.Errorf("`async_trigger_config.retry_config` should have at least one item")
+	}
+	for i, item := range retryConfigs {
+		retryConfigMap, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("`async_trigger_config.retry_config.%d.retry_num` is required", i)
+		}
+		retryNum, ok := retryConfigMap["retry_num"].(int)
+		if !ok {
+			return nil, fmt.Errorf("`async_trigger_config.retry_config.%d.retry_num` is required", i)
+		}
+		asyncTriggerConfig.RetryConfig = append(asyncTriggerConfig.RetryConfig, &scf.RetryConfig{
+			RetryNum: helper.IntInt64(retryNum),
+		})
+	}
+
+	msgTtl, ok := dMap["msg_ttl"].(int)
+	if !ok {
+		return nil, fmt.Errorf("`async_trigger_config.msg_ttl` is required")
+	}
+	asyncTriggerConfig.MsgTTL = helper.IntInt64(msgTtl)
+
+	return &asyncTriggerConfig, nil
+}
+
 func resourceTencentCloudScfFunctionEventInvokeConfigDelete(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_scf_function_event_invoke_config.delete")()
 	defer inconsistentCheck(d, meta)()
 
+	logId := getLogId(contextNil)
+
+	idSplit, err := helper.ParseCompositeId(d.Id(), 2, "functionName", "namespace")
+	if err != nil {
+		return err
+	}
+	functionName := idSplit[0]
+	namespace := idSplit[1]
+
+	// there is no API to delete the config, so the defaults are restored instead
+	request := scf.NewUpdateFunctionEventInvokeConfigRequest()
+	request.Namespace = &namespace
+	request.FunctionName = &functionName
+	request.AsyncTriggerConfig = &scf.AsyncTriggerConfig{
+		RetryConfig: []*scf.RetryConfig{
+			{RetryNum: helper.IntInt64(SCF_ASYNC_DEFAULT_RETRY_NUM)},
+		},
+		MsgTTL: helper.IntInt64(SCF_ASYNC_DEFAULT_MSG_TTL),
+	}
+
+	err = resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+		result, e := meta.(*TencentCloudClient).apiV3Conn.UseScfClient().UpdateFunctionEventInvokeConfig(request)
+		if e != nil {
+			if isExpectError(e, SCF_FUNCTIONS_NOT_FOUND_SET) {
+				log.Printf("[WARN]%s function [%s] of namespace [%s] not found, nothing to reset\n", logId, functionName, namespace)
+				return nil
+			}
+			return retryError(e)
+		} else {
+			log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), result.ToJsonString())
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("[CRITAL]%s delete scf FunctionEventInvokeConfig failed, reason:%+v", logId, err)
+		return err
+	}
+
+	return nil
+}
+
+// resourceTencentCloudScfFunctionEventInvokeConfigMsgTtlDiff refuses a msg_ttl which leaves too little time for the retries
+// of any retry_config entry, it only runs when msg_ttl_check is enabled.
+func resourceTencentCloudScfFunctionEventInvokeConfigMsgTtlDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("msg_ttl_check").(bool) {
+		return nil
+	}
+	msgTtl, ok := d.Get("async_trigger_config.0.msg_ttl").(int)
+	if !ok {
+		return nil
+	}
+	retryConfigs, _ := d.Get("async_trigger_config.0.retry_config").([]interface{})
+	retryNums := make([]int, 0, len(retryConfigs))
+	for _, item := range retryConfigs {
+		if retryConfig, ok := item.(map[string]interface{}); ok {
+			retryNum, _ := retryConfig["retry_num"].(int)
+			retryNums = append(retryNums, retryNum)
+		}
+	}
+
+	ttlPerRetry := SCF_ASYNC_RETRY_TTL_PER_RETRY
+	if v, ok := os.LookupEnv(PROVIDER_SCF_ASYNC_RETRY_TTL_PER_RETRY); ok {
+		if i, err := strconv.Atoi(v); err == nil && i >= 0 {
+			ttlPerRetry = i
+		}
+	}
+
+	return checkScfAsyncRetryMsgTtl(msgTtl, retryNums, ttlPerRetry)
+}
+
+// checkScfAsyncRetryMsgTtl returns an error if msgTtl leaves less than ttlPerRetry seconds for each retry of any entry.
+func checkScfAsyncRetryMsgTtl(msgTtl int, retryNums []int, ttlPerRetry int) error {
+	for i, retryNum := range retryNums {
+		if retryNum > 0 && msgTtl < retryNum*ttlPerRetry {
+			return fmt.Errorf("msg_ttl %d is too small for async_trigger_config.0.retry_config.%d.retry_num %d, "+
+				"messages may expire before all retries are done, at least %d is required. Set `msg_ttl_check = false` to apply it anyway",
+				msgTtl, i, retryNum, retryNum*ttlPerRetry)
+		}
+	}
 	return nil
 }
@@ -1,9 +1,14 @@
 package tencentcloud
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 )
 
 func TestAccTencentCloudNeedFixScfFunctionEventInvokeConfigResource_basic(t *testing.T) {
@@ -12,11 +17,15 @@ func TestAccTencentCloudNeedFixScfFunctionEventInvokeConfigResource_basic(t *tes
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
-		Providers: testAccProviders,
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckScfFunctionEventInvokeConfigDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccScfFunctionEventInvokeConfig,
-				Check:  resource.ComposeTestCheckFunc(resource.TestCheckResourceAttrSet("tencentcloud_scf_function_event_invoke_config.function_event_invoke_config", "id")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_scf_function_event_invoke_config.function_event_invoke_config", "id"),
+					resource.TestCheckResourceAttrSet("tencentcloud_scf_function_event_invoke_config.function_event_invoke_config", "function_id"),
+				),
 			},
 			{
 				ResourceName:      "tencentcloud_scf_function_event_invoke_config.function_event_invoke_config",
@@ -27,6 +36,104 @@ func TestAccTencentCloudNeedFixScfFunctionEventInvokeConfigResource_basic(t *tes
 	})
 }
 
+func TestParseScfFunctionEventInvokeConfigImportId(t *testing.T) {
+	cases := map[string][2]string{
+		"keep-1676351130#default": {"keep-1676351130", "default"},
+		"keep-1676351130#":        {"keep-1676351130", "default"},
+		"keep-1676351130#test":    {"keep-1676351130", "test"},
+	}
+	for id, expected := range cases {
+		functionName, namespace, err := parseScfFunctionEventInvokeConfigImportId(id)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", id, err)
+		}
+		if functionName != expected[0] || namespace != expected[1] {
+			t.Fatalf("%s: expected %v, got [%s %s]", id, expected, functionName, namespace)
+		}
+	}
+
+	for _, id := range []string{"", "keep-1676351130", "#default", "keep-1676351130#default#extra", "keep-1676351130##"} {
+		if _, _, err := parseScfFunctionEventInvokeConfigImportId(id); err == nil {
+			t.Fatalf("%s: expected error", id)
+		}
+	}
+}
+
+func TestBuildScfAsyncTriggerConfig(t *testing.T) {
+	res := resourceTencentCloudScfFunctionEventInvokeConfig()
+
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"function_name": "keep-1676351130",
+		"async_trigger_config": []interface{}{
+			map[string]interface{}{
+				"retry_config": []interface{}{
+					map[string]interface{}{"retry_num": 2},
+				},
+				"msg_ttl": 24,
+			},
+		},
+	})
+	config, err := buildScfAsyncTriggerConfig(d)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(config.RetryConfig) != 1 || *config.RetryConfig[0].RetryNum != 2 || *config.MsgTTL != 24 {
+		t.Fatalf("unexpected config %+v", config)
+	}
+
+	cases := map[string]map[string]interface{}{
+		"missing block": {
+			"function_name": "keep-1676351130",
+		},
+		"empty retry_config": {
+			"function_name": "keep-1676351130",
+			"async_trigger_config": []interface{}{
+				map[string]interface{}{
+					"retry_config": []interface{}{},
+					"msg_ttl":      24,
+				},
+			},
+		},
+	}
+	for name, raw := range cases {
+		d := schema.TestResourceDataRaw(t, res.Schema, raw)
+		if _, err := buildScfAsyncTriggerConfig(d); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func testAccCheckScfFunctionEventInvokeConfigDestroy(s *terraform.State) error {
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+	service := ScfService{client: testAccProvider.Meta().(*TencentCloudClient).apiV3Conn}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "tencentcloud_scf_function_event_invoke_config" {
+			continue
+		}
+		idSplit, err := helper.ParseCompositeId(rs.Primary.ID, 2, "functionName", "namespace")
+		if err != nil {
+			return err
+		}
+		config, err := service.DescribeScfFunctionEventInvokeConfigById(ctx, idSplit[1], idSplit[0])
+		if err != nil {
+			return err
+		}
+		if config == nil {
+			continue
+		}
+		var retryNum int64
+		if len(config.RetryConfig) > 0 {
+			retryNum = helper.PInt64(config.RetryConfig[0].RetryNum)
+		}
+		if retryNum != SCF_ASYNC_DEFAULT_RETRY_NUM || helper.PInt64(config.MsgTTL) != SCF_ASYNC_DEFAULT_MSG_TTL {
+			return fmt.Errorf("scf function_event_invoke_config %s is not reset to the default, got retry_num %d and msg_ttl %d",
+				rs.Primary.ID, retryNum, helper.PInt64(config.MsgTTL))
+		}
+	}
+	return nil
+}
+
 const testAccScfFunctionEventInvokeConfig = `
 
 resource "tencentcloud_scf_function_event_invoke_config" "function_event_invoke_config" {
@@ -41,3 +148,41 @@ resource "tencentcloud_scf_function_event_invoke_config" "function_event_invoke_
 }
 
 `
+
+func TestCheckScfAsyncRetryMsgTtl(t *testing.T) {
+	cases := []struct {
+		name      string
+		msgTtl    int
+		retryNums []int
+		reject    bool
+	}{
+		{"enough ttl", 120, []int{2}, false},
+		{"too small ttl", 119, []int{2}, true},
+		{"no retry", 0, []int{0}, false},
+		{"second entry too large", 120, []int{1, 3}, true},
+		{"every entry fits", 180, []int{1, 3}, false},
+	}
+	for _, c := range cases {
+		err := checkScfAsyncRetryMsgTtl(c.msgTtl, c.retryNums, 60)
+		if (err != nil) != c.reject {
+			t.Errorf("%s: expected reject %v, got %v", c.name, c.reject, err)
+		}
+	}
+}
+
+func TestScfFunctionRecreated(t *testing.T) {
+	cases := []struct {
+		oldId, newId string
+		recreated    bool
+	}{
+		{"", "fid-1", false},
+		{"fid-1", "", false},
+		{"fid-1", "fid-1", false},
+		{"fid-1", "fid-2", true},
+	}
+	for _, c := range cases {
+		if got := scfFunctionRecreated(c.oldId, c.newId); got != c.recreated {
+			t.Errorf("scfFunctionRecreated(%q, %q) = %v, want %v", c.oldId, c.newId, got, c.recreated)
+		}
+	}
+}
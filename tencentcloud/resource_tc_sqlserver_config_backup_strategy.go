@@ -175,30 +175,35 @@ func resourceTencentCloudSqlserverConfigBackupStrategy() *schema.Resource {
 
 			"regular_backup_enable": {
 				Optional:    true,
+				Computed:    true,
 				Type:        schema.TypeString,
 				Description: "Archive backup status. Valid values: enable (enabled); disable (disabled). Default value: disable.",
 			},
 
 			"regular_backup_save_days": {
 				Optional:    true,
+				Computed:    true,
 				Type:        schema.TypeInt,
 				Description: "Archive backup retention days. Value range: 90-3650 days. Default value: 365 days.",
 			},
 
 			"regular_backup_strategy": {
 				Optional:    true,
+				Computed:    true,
 				Type:        schema.TypeString,
 				Description: "Archive backup policy. Valid values: years (yearly); quarters (quarterly); months(monthly); Default value: `months`.",
 			},
 
 			"regular_backup_counts": {
 				Optional:    true,
+				Computed:    true,
 				Type:        schema.TypeInt,
 				Description: "The number of retained archive backups. Default value: 1.",
 			},
 
 			"regular_backup_start_time": {
 				Optional:    true,
+				Computed:    true,
 				Type:        schema.TypeString,
 				Description: "Archive backup start date in YYYY-MM-DD format, which is the current time by default.",
 			},
@@ -271,25 +276,33 @@ func resourceTencentCloudSqlserverConfigBackupStrategyRead(d *schema.ResourceDat
 		_ = d.Set("backup_save_days", configBackupStrategy.BackupSaveDays)
 	}
 
-	// if configBackupStrategy.RegularBackupEnable != nil {
-	// 	_ = d.Set("regular_backup_enable", configBackupStrategy.RegularBackupEnable)
-	// }
+	// the regular backup settings are not returned by DescribeDBInstances, read them from the instance attributes
+	insAttribute, err := service.DescribeSqlserverInsAttributeByFilter(ctx, map[string]interface{}{"InstanceId": helper.String(instanceId)})
+	if err != nil {
+		return err
+	}
+
+	if insAttribute != nil {
+		if insAttribute.RegularBackupEnable != nil {
+			_ = d.Set("regular_backup_enable", insAttribute.RegularBackupEnable)
+		}
 
-	// if configBackupStrategy.RegularBackupSaveDays != nil {
-	// 	_ = d.Set("regular_backup_save_days", configBackupStrategy.RegularBackupSaveDays)
-	// }
+		if insAttribute.RegularBackupSaveDays != nil {
+			_ = d.Set("regular_backup_save_days", insAttribute.RegularBackupSaveDays)
+		}
 
-	// if configBackupStrategy.RegularBackupStrategy != nil {
-	// 	_ = d.Set("regular_backup_strategy", configBackupStrategy.RegularBackupStrategy)
-	// }
+		if insAttribute.RegularBackupStrategy != nil {
+			_ = d.Set("regular_backup_strategy", insAttribute.RegularBackupStrategy)
+		}
 
-	// if configBackupStrategy.RegularBackupCounts != nil {
-	// 	_ = d.Set("regular_backup_counts", configBackupStrategy.RegularBackupCounts)
-	// }
+		if insAttribute.RegularBackupCounts != nil {
+			_ = d.Set("regular_backup_counts", insAttribute.RegularBackupCounts)
+		}
 
-	// if configBackupStrategy.RegularBackupStartTime != nil {
-	// 	_ = d.Set("regular_backup_start_time", configBackupStrategy.RegularBackupStartTime)
-	// }
+		if insAttribute.RegularBackupStartTime != nil {
+			_ = d.Set("regular_backup_start_time", insAttribute.RegularBackupStartTime)
+		}
+	}
 
 	return nil
 }
@@ -51,6 +51,9 @@ func resourceTencentCloudSsmProductSecret() *schema.Resource {
 		Read:   resourceTencentCloudSsmProductSecretRead,
 		Update: resourceTencentCloudSsmProductSecretUpdate,
 		Delete: resourceTencentCloudSsmProductSecretDelete,
+
+		CustomizeDiff: resourceTencentCloudSsmProductSecretPrivilegesDiff,
+
 		Schema: map[string]*schema.Schema{
 			"secret_name": {
 				Required:    true,
@@ -93,9 +96,10 @@ func resourceTencentCloudSsmProductSecret() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"privilege_name": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Permission name. Valid values: `GlobalPrivileges`, `DatabasePrivileges`, `TablePrivileges`, `ColumnPrivileges`. When the permission is `DatabasePrivileges`, the database name must be specified by the `Database` parameter; When the permission is `TablePrivileges`, the database name and the table name in the database must be specified by the `Database` and `TableName` parameters; When the permission is `ColumnPrivileges`, the database name, table name in the database, and column name in the table must be specified by the `Database`, `TableName`, and `ColumnName` parameters.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateAllowedStringValue(SSM_PRIVILEGE_NAMES),
+							Description:  "Permission name. Valid values: `GlobalPrivileges`, `DatabasePrivileges`, `TablePrivileges`, `ColumnPrivileges`. When the permission is `DatabasePrivileges`, the database name must be specified by the `Database` parameter; When the permission is `TablePrivileges`, the database name and the table name in the database must be specified by the `Database` and `TableName` parameters; When the permission is `ColumnPrivileges`, the database name, table name in the database, and column name in the table must be specified by the `Database`, `TableName`, and `ColumnName` parameters.",
 						},
 						"privileges": {
 							Type: schema.TypeSet,
@@ -108,7 +112,7 @@ func resourceTencentCloudSsmProductSecret() *schema.Resource {
 						"database": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							Description: "This value takes effect only when `PrivilegeName` is `DatabasePrivileges`.",
+							Description: "Database name. Required when `privilege_name` is `DatabasePrivileges`, `TablePrivileges` or `ColumnPrivileges`, and can not be set when `privilege_name` is `GlobalPrivileges`.",
 						},
 						"table_name": {
 							Type:        schema.TypeString,
@@ -229,13 +233,13 @@ func resourceTencentCloudSsmProductSecretCreate(d *schema.ResourceData, meta int
 					productPrivilegeUnit.Privileges = append(productPrivilegeUnit.Privileges, &privileges)
 				}
 			}
-			if v, ok := dMap["database"]; ok {
+			if v, ok := dMap["database"]; ok && v.(string) != "" {
 				productPrivilegeUnit.Database = helper.String(v.(string))
 			}
-			if v, ok := dMap["table_name"]; ok {
+			if v, ok := dMap["table_name"]; ok && v.(string) != "" {
 				productPrivilegeUnit.TableName = helper.String(v.(string))
 			}
-			if v, ok := dMap["column_name"]; ok {
+			if v, ok := dMap["column_name"]; ok && v.(string) != "" {
 				productPrivilegeUnit.ColumnName = helper.String(v.(string))
 			}
 			request.PrivilegesList = append(request.PrivilegesList, &productPrivilegeUnit)
@@ -341,14 +345,21 @@ func resourceTencentCloudSsmProductSecretRead(d *schema.ResourceData, meta inter
 		_ = d.Set("instance_id", productSecret.ResourceID)
 	}
 
+	// description may be cleared remotely, always set it to detect drift
+	description := ""
 	if productSecret.Description != nil {
-		_ = d.Set("description", productSecret.Description)
+		description = *productSecret.Description
 	}
+	_ = d.Set("description", description)
 
 	if productSecret.KmsKeyId != nil {
 		_ = d.Set("kms_key_id", productSecret.KmsKeyId)
 	}
 
+	if productSecret.Status != nil {
+		_ = d.Set("status", productSecret.Status)
+	}
+
 	if productSecret.RotationBeginTime != nil {
 		_ = d.Set("rotation_begin_time", productSecret.RotationBeginTime)
 	}
@@ -398,10 +409,8 @@ func resourceTencentCloudSsmProductSecretUpdate(d *schema.ResourceData, meta int
 	if d.HasChange("description") {
 		request := ssm.NewUpdateDescriptionRequest()
 		request.SecretName = &secretName
-
-		if v, ok := d.GetOk("description"); ok {
-			request.Description = helper.String(v.(string))
-		}
+		// empty string is allowed to clear the description
+		request.Description = helper.String(d.Get("description").(string))
 
 		err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
 			result, e := meta.(*TencentCloudClient).apiV3Conn.UseSsmClient().UpdateDescription(request)
@@ -493,3 +502,77 @@ func resourceTencentCloudSsmProductSecretDelete(d *schema.ResourceData, meta int
 
 	return nil
 }
+
+func resourceTencentCloudSsmProductSecretPrivilegesDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	privilegesList, _ := d.Get("privileges_list").([]interface{})
+	for i, item := range privilegesList {
+		dMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		// skip the entries whose values are only known after apply
+		known := true
+		for _, key := range []string{"privilege_name", "database", "table_name", "column_name"} {
+			if !d.NewValueKnown(fmt.Sprintf("privileges_list.%d.%s", i, key)) {
+				known = false
+				break
+			}
+		}
+		if !known {
+			continue
+		}
+
+		unit := ssm.ProductPrivilegeUnit{}
+		if v, ok := dMap["privilege_name"].(string); ok {
+			unit.PrivilegeName = helper.String(v)
+		}
+		if v, ok := dMap["database"].(string); ok && v != "" {
+			unit.Database = helper.String(v)
+		}
+		if v, ok := dMap["table_name"].(string); ok && v != "" {
+			unit.TableName = helper.String(v)
+		}
+		if v, ok := dMap["column_name"].(string); ok && v != "" {
+			unit.ColumnName = helper.String(v)
+		}
+		if err := checkSsmProductPrivilegeUnit(&unit); err != nil {
+			return fmt.Errorf("privileges_list.%d: %s", i, err)
+		}
+	}
+
+	return nil
+}
+
+func checkSsmProductPrivilegeUnit(unit *ssm.ProductPrivilegeUnit) error {
+	privilegeName := helper.PString(unit.PrivilegeName)
+	hasDatabase := unit.Database != nil
+	hasTable := unit.TableName != nil
+	hasColumn := unit.ColumnName != nil
+
+	switch privilegeName {
+	case SSM_PRIVILEGE_GLOBAL:
+		if hasDatabase || hasTable || hasColumn {
+			return fmt.Errorf("`database`, `table_name` and `column_name` can not be set when `privilege_name` is `%s`", privilegeName)
+		}
+	case SSM_PRIVILEGE_DATABASE:
+		if !hasDatabase {
+			return fmt.Errorf("`database` is required when `privilege_name` is `%s`", privilegeName)
+		}
+		if hasTable || hasColumn {
+			return fmt.Errorf("`table_name` and `column_name` can not be set when `privilege_name` is `%s`", privilegeName)
+		}
+	case SSM_PRIVILEGE_TABLE:
+		if !hasDatabase || !hasTable {
+			return fmt.Errorf("`database` and `table_name` are required when `privilege_name` is `%s`", privilegeName)
+		}
+		if hasColumn {
+			return fmt.Errorf("`column_name` can not be set when `privilege_name` is `%s`", privilegeName)
+		}
+	case SSM_PRIVILEGE_COLUMN:
+		if !hasDatabase || !hasTable || !hasColumn {
+			return fmt.Errorf("`database`, `table_name` and `column_name` are required when `privilege_name` is `%s`", privilegeName)
+		}
+	}
+
+	return nil
+}
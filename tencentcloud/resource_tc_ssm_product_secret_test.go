@@ -1,12 +1,16 @@
 package tencentcloud
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccTencentCloudSsmProductSecretResource_basic(t *testing.T) {
+	var createTime string
 	t.Parallel()
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -28,10 +32,53 @@ func TestAccTencentCloudSsmProductSecretResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("tencentcloud_ssm_product_secret.product_secret", "status", "Enabled"),
 				),
 			},
+			{
+				Config: testAccSsmProductSecretUpdateDescription,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_ssm_product_secret.product_secret", "description", ""),
+					resource.TestCheckResourceAttr("tencentcloud_ssm_product_secret.product_secret", "status", "Enabled"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccSsmProductSecretRotation, 30),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_ssm_product_secret.product_secret", "enable_rotation", "true"),
+					resource.TestCheckResourceAttr("tencentcloud_ssm_product_secret.product_secret", "rotation_frequency", "30"),
+					testAccCheckSsmProductSecretCreateTime("tencentcloud_ssm_product_secret.product_secret", &createTime),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccSsmProductSecretRotation, 60),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_ssm_product_secret.product_secret", "enable_rotation", "true"),
+					resource.TestCheckResourceAttr("tencentcloud_ssm_product_secret.product_secret", "rotation_frequency", "60"),
+					// changing the frequency must not recreate the secret
+					testAccCheckSsmProductSecretCreateTime("tencentcloud_ssm_product_secret.product_secret", &createTime),
+				),
+			},
 		},
 	})
 }
 
+func testAccCheckSsmProductSecretCreateTime(r string, createTime *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[r]
+		if !ok {
+			return fmt.Errorf("resource %s is not found", r)
+		}
+
+		current := rs.Primary.Attributes["create_time"]
+		if *createTime == "" {
+			*createTime = current
+			return nil
+		}
+		if *createTime != current {
+			return fmt.Errorf("ssm product secret %s was recreated, create_time changed from %s to %s", rs.Primary.ID, *createTime, current)
+		}
+		return nil
+	}
+}
+
 const testAccSsmProductSecret = `
 
 data "tencentcloud_kms_keys" "kms" {
@@ -52,9 +99,9 @@ resource "tencentcloud_ssm_product_secret" "product_secret" {
     privilege_name = "GlobalPrivileges"
     privileges     = ["ALTER ROUTINE"]
   }
-  description         = "for ssm product test"
-  kms_key_id          = data.tencentcloud_kms_keys.kms.key_list.0.key_id
-  status              = "Disabled"
+  description = "for ssm product test"
+  kms_key_id  = data.tencentcloud_kms_keys.kms.key_list.0.key_id
+  status      = "Disabled"
 }
 
 `
@@ -79,9 +126,126 @@ resource "tencentcloud_ssm_product_secret" "product_secret" {
     privilege_name = "GlobalPrivileges"
     privileges     = ["ALTER ROUTINE"]
   }
-  description         = "for ssm product"
-  kms_key_id          = data.tencentcloud_kms_keys.kms.key_list.0.key_id
-  status              = "Enabled"
+  description = "for ssm product"
+  kms_key_id  = data.tencentcloud_kms_keys.kms.key_list.0.key_id
+  status      = "Enabled"
+}
+
+`
+
+const testAccSsmProductSecretUpdateDescription = `
+
+data "tencentcloud_kms_keys" "kms" {
+  key_state = 1
+}
+
+data "tencentcloud_mysql_instance" "mysql" {
+  mysql_id = "cdb-fitq5t9h"
+}
+
+resource "tencentcloud_ssm_product_secret" "product_secret" {
+  secret_name      = "tf-product-ssm-test"
+  user_name_prefix = "test"
+  product_name     = "Mysql"
+  instance_id      = data.tencentcloud_mysql_instance.mysql.instance_list.0.mysql_id
+  domains          = ["10.0.0.0"]
+  privileges_list {
+    privilege_name = "GlobalPrivileges"
+    privileges     = ["ALTER ROUTINE"]
+  }
+  description = ""
+  kms_key_id  = data.tencentcloud_kms_keys.kms.key_list.0.key_id
+  status      = "Enabled"
+}
+
+`
+
+const testAccSsmProductSecretRotation = `
+
+data "tencentcloud_kms_keys" "kms" {
+  key_state = 1
+}
+
+data "tencentcloud_mysql_instance" "mysql" {
+  mysql_id = "cdb-fitq5t9h"
+}
+
+resource "tencentcloud_ssm_product_secret" "product_secret" {
+  secret_name      = "tf-product-ssm-test"
+  user_name_prefix = "test"
+  product_name     = "Mysql"
+  instance_id      = data.tencentcloud_mysql_instance.mysql.instance_list.0.mysql_id
+  domains          = ["10.0.0.0"]
+  privileges_list {
+    privilege_name = "GlobalPrivileges"
+    privileges     = ["ALTER ROUTINE"]
+  }
+  description        = ""
+  kms_key_id         = data.tencentcloud_kms_keys.kms.key_list.0.key_id
+  status             = "Enabled"
+  enable_rotation    = true
+  rotation_frequency = %d
 }
 
 `
+
+func TestSsmProductSecretPrivilegesDiff(t *testing.T) {
+	cases := []struct {
+		name      string
+		privilege map[string]interface{}
+		wantErr   bool
+	}{
+		{"global", map[string]interface{}{"privilege_name": "GlobalPrivileges"}, false},
+		{"global with database", map[string]interface{}{"privilege_name": "GlobalPrivileges", "database": "db"}, true},
+		{"database", map[string]interface{}{"privilege_name": "DatabasePrivileges", "database": "db"}, false},
+		{"database without database", map[string]interface{}{"privilege_name": "DatabasePrivileges"}, true},
+		{"database with table", map[string]interface{}{"privilege_name": "DatabasePrivileges", "database": "db", "table_name": "tb"}, true},
+		{"table", map[string]interface{}{"privilege_name": "TablePrivileges", "database": "db", "table_name": "tb"}, false},
+		{"table without table", map[string]interface{}{"privilege_name": "TablePrivileges", "database": "db"}, true},
+		{"table with column", map[string]interface{}{"privilege_name": "TablePrivileges", "database": "db", "table_name": "tb", "column_name": "col"}, true},
+		{"column", map[string]interface{}{"privilege_name": "ColumnPrivileges", "database": "db", "table_name": "tb", "column_name": "col"}, false},
+		{"column without column", map[string]interface{}{"privilege_name": "ColumnPrivileges", "database": "db", "table_name": "tb"}, true},
+	}
+
+	// an existing secret makes the same checks apply when privileges_list is updated
+	states := map[string]*terraform.InstanceState{
+		"create": nil,
+		"update": {
+			ID: "tf-product-secret",
+			Attributes: map[string]string{
+				"id":                               "tf-product-secret",
+				"secret_name":                      "tf-product-secret",
+				"user_name_prefix":                 "tf",
+				"product_name":                     "Mysql",
+				"instance_id":                      "cdb-xxxxxxxx",
+				"privileges_list.#":                "1",
+				"privileges_list.0.privilege_name": "GlobalPrivileges",
+				"privileges_list.0.privileges.#":   "1",
+				"privileges_list.0.privileges.0":   "SELECT",
+				"privileges_list.0.database":       "",
+				"privileges_list.0.table_name":     "",
+				"privileges_list.0.column_name":    "",
+			},
+		},
+	}
+
+	for stateName, state := range states {
+		for _, c := range cases {
+			privilege := map[string]interface{}{"privileges": []interface{}{"INSERT"}}
+			for k, v := range c.privilege {
+				privilege[k] = v
+			}
+			raw := map[string]interface{}{
+				"secret_name":      "tf-product-secret",
+				"user_name_prefix": "tf",
+				"product_name":     "Mysql",
+				"instance_id":      "cdb-xxxxxxxx",
+				"privileges_list":  []interface{}{privilege},
+			}
+			_, err := resourceTencentCloudSsmProductSecret().Diff(context.TODO(), state, terraform.NewResourceConfigRaw(raw), nil)
+			if (err != nil) != c.wantErr {
+				t.Errorf("%s %s: expected error %v, got %v", stateName, c.name, c.wantErr, err)
+			}
+		}
+	}
+}
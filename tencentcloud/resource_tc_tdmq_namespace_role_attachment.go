@@ -36,13 +36,20 @@ resource "tencentcloud_tdmq_namespace_role_attachment" "example" {
   cluster_id  = tencentcloud_tdmq_instance.example.id
 }
 ```
+
+Import
+
+tdmq namespace role attachment can be imported using the environId#roleName#clusterId, e.g.
+
+```
+$ terraform import tencentcloud_tdmq_namespace_role_attachment.example tf_example#tf_example#pulsar-xxxxxxxx
+```
 */
 package tencentcloud
 
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -57,29 +64,36 @@ func resourceTencentCloudTdmqNamespaceRoleAttachment() *schema.Resource {
 		Update: resourceTencentCloudTdmqNamespaceRoleAttachmentUpdate,
 		Delete: resourceTencentCloudTdmqNamespaceRoleAttachmentDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceTencentCloudTdmqNamespaceRoleAttachmentImport,
 		},
+		CustomizeDiff: resourceTencentCloudTdmqNamespaceRoleAttachmentPermissionsDiff,
 
 		Schema: map[string]*schema.Schema{
 			"environ_id": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "The name of tdmq namespace.",
 			},
 			"role_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "The name of tdmq role.",
 			},
 			"permissions": {
-				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateAllowedStringValue(TDMQ_NAMESPACE_ROLE_PERMISSIONS),
+				},
 				Required:    true,
-				Description: "The permissions of tdmq role.",
+				Description: "The permissions of tdmq role. Valid values: `produce`, `consume`, each of them can be set only once.",
 			},
 			"cluster_id": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "The id of tdmq cluster.",
 			},
 			//compute
@@ -143,6 +157,17 @@ func resourceTencentCloudTdmqNamespaceRoleAttachmentCreate(d *schema.ResourceDat
 	return resourceTencentCloudTdmqNamespaceRoleAttachmentRead(d, meta)
 }
 
+func resourceTencentCloudTdmqNamespaceRoleAttachmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// cluster_id is not part of the resource id, so the import id has to carry it
+	idSplit, err := helper.ParseCompositeId(d.Id(), 3, "environId", "roleName", "clusterId")
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(idSplit[0] + FILED_SP + idSplit[1])
+	_ = d.Set("cluster_id", idSplit[2])
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceTencentCloudTdmqNamespaceRoleAttachmentRead(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_tdmq_namespace_role_attachment.read")()
 	defer inconsistentCheck(d, meta)()
@@ -150,9 +175,9 @@ func resourceTencentCloudTdmqNamespaceRoleAttachmentRead(d *schema.ResourceData,
 	logId := getLogId(contextNil)
 	ctx := context.WithValue(context.TODO(), logIdKey, logId)
 
-	idSplit := strings.Split(d.Id(), FILED_SP)
-	if len(idSplit) != 2 {
-		return fmt.Errorf("environment role id is borken, id is %s", d.Id())
+	idSplit, err := helper.ParseCompositeId(d.Id(), 2, "environId", "roleName")
+	if err != nil {
+		return err
 	}
 	environId := idSplit[0]
 	roleName := idSplit[1]
@@ -160,7 +185,7 @@ func resourceTencentCloudTdmqNamespaceRoleAttachmentRead(d *schema.ResourceData,
 
 	tdmqService := TdmqService{client: meta.(*TencentCloudClient).apiV3Conn}
 
-	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+	err = resource.Retry(readRetryTimeout, func() *resource.RetryError {
 		info, has, e := tdmqService.DescribeTdmqNamespaceRoleAttachment(ctx, environId, roleName, clusterId)
 		if e != nil {
 			return retryError(e)
@@ -187,9 +212,9 @@ func resourceTencentCloudTdmqNamespaceRoleAttachmentUpdate(d *schema.ResourceDat
 	logId := getLogId(contextNil)
 	ctx := context.WithValue(context.TODO(), logIdKey, logId)
 
-	idSplit := strings.Split(d.Id(), FILED_SP)
-	if len(idSplit) != 2 {
-		return fmt.Errorf("environment role id is borken, id is %s", d.Id())
+	idSplit, err := helper.ParseCompositeId(d.Id(), 2, "environId", "roleName")
+	if err != nil {
+		return err
 	}
 	environId := idSplit[0]
 	roleName := idSplit[1]
@@ -227,9 +252,9 @@ func resourceTencentCloudTdmqNamespaceRoleAttachmentDelete(d *schema.ResourceDat
 	logId := getLogId(contextNil)
 	ctx := context.WithValue(context.TODO(), logIdKey, logId)
 
-	idSplit := strings.Split(d.Id(), FILED_SP)
-	if len(idSplit) != 2 {
-		return fmt.Errorf("environment role id is borken, id is %s", d.Id())
+	idSplit, err := helper.ParseCompositeId(d.Id(), 2, "environId", "roleName")
+	if err != nil {
+		return err
 	}
 	environId := idSplit[0]
 	roleName := idSplit[1]
@@ -237,7 +262,7 @@ func resourceTencentCloudTdmqNamespaceRoleAttachmentDelete(d *schema.ResourceDat
 
 	service := TdmqService{client: meta.(*TencentCloudClient).apiV3Conn}
 
-	err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+	err = resource.Retry(writeRetryTimeout, func() *resource.RetryError {
 		if err := service.DeleteTdmqNamespaceRoleAttachment(ctx, environId, roleName, clusterId); err != nil {
 			if sdkErr, ok := err.(*errors.TencentCloudSDKError); ok {
 				if sdkErr.Code == VPCNotFound {
@@ -251,3 +276,23 @@ func resourceTencentCloudTdmqNamespaceRoleAttachmentDelete(d *schema.ResourceDat
 
 	return err
 }
+
+func resourceTencentCloudTdmqNamespaceRoleAttachmentPermissionsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("permissions") {
+		return nil
+	}
+	return checkTdmqNamespaceRolePermissions(d.Get("permissions").([]interface{}))
+}
+
+// checkTdmqNamespaceRolePermissions returns an error if a permission is set more than once.
+func checkTdmqNamespaceRolePermissions(permissions []interface{}) error {
+	seen := make(map[string]bool, len(permissions))
+	for _, v := range permissions {
+		permission, _ := v.(string)
+		if seen[permission] {
+			return fmt.Errorf("permissions: `%s` is duplicated", permission)
+		}
+		seen[permission] = true
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package tencentcloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestCheckTdmqNamespaceRolePermissions(t *testing.T) {
+	if err := checkTdmqNamespaceRolePermissions([]interface{}{"produce", "consume"}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := checkTdmqNamespaceRolePermissions([]interface{}{"produce", "consume", "produce"}); err == nil {
+		t.Errorf("expected an error for duplicated permissions")
+	}
+
+	validate := resourceTencentCloudTdmqNamespaceRoleAttachment().Schema["permissions"].Elem.(*schema.Schema).ValidateFunc
+	if _, errs := validate("produce", "permissions.0"); len(errs) != 0 {
+		t.Errorf("expected produce to be valid, got %v", errs)
+	}
+	if _, errs := validate("prodcue", "permissions.0"); len(errs) == 0 {
+		t.Errorf("expected prodcue to be rejected")
+	}
+}
+
+// go test -i; go test -test.run TestAccTencentCloudTdmqNamespaceRoleAttachmentResource_basic -v
+func TestAccTencentCloudTdmqNamespaceRoleAttachmentResource_basic(t *testing.T) {
+	t.Parallel()
+	terraformId := "tencentcloud_tdmq_namespace_role_attachment.example"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTdmqNamespaceRoleAttachment,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(terraformId, "cluster_id"),
+					resource.TestCheckResourceAttr(terraformId, "permissions.#", "2"),
+				),
+			},
+			{
+				ResourceName:      terraformId,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[terraformId]
+					if !ok {
+						return "", fmt.Errorf("resource %s is not found", terraformId)
+					}
+					return rs.Primary.ID + FILED_SP + rs.Primary.Attributes["cluster_id"], nil
+				},
+			},
+			{
+				ResourceName:  terraformId,
+				ImportState:   true,
+				ImportStateId: "tf_example" + FILED_SP + "tf_example",
+				ExpectError:   regexp.MustCompile("expected format is `environId#roleName#clusterId`"),
+			},
+		},
+	})
+}
+
+const testAccTdmqNamespaceRoleAttachment = `
+resource "tencentcloud_tdmq_instance" "example" {
+  cluster_name = "tf_example"
+  remark       = "remark."
+  tags         = {
+    "createdBy" = "terraform"
+  }
+}
+
+resource "tencentcloud_tdmq_namespace" "example" {
+  environ_name = "tf_example"
+  msg_ttl      = 300
+  cluster_id   = tencentcloud_tdmq_instance.example.id
+  retention_policy {
+    time_in_minutes = 60
+    size_in_mb      = 10
+  }
+  remark = "remark."
+}
+
+resource "tencentcloud_tdmq_role" "example" {
+  role_name  = "tf_example"
+  cluster_id = tencentcloud_tdmq_instance.example.id
+  remark     = "remark."
+}
+
+resource "tencentcloud_tdmq_namespace_role_attachment" "example" {
+  environ_id  = tencentcloud_tdmq_namespace.example.environ_name
+  role_name   = tencentcloud_tdmq_role.example.role_name
+  permissions = ["produce", "consume"]
+  cluster_id  = tencentcloud_tdmq_instance.example.id
+}
+`
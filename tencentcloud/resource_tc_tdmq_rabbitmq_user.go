@@ -14,6 +14,16 @@ resource "tencentcloud_tdmq_rabbitmq_user" "rabbitmq_user" {
   max_channels    = 3
 }
 ```
+
+Import
+
+tdmq rabbitmq_user can be imported using the id, e.g.
+
+~> **NOTE:** The password, `max_connections` and `max_channels` can not be read back from the API, so they are not imported and must be supplied in the configuration after import.
+
+```
+terraform import tencentcloud_tdmq_rabbitmq_user.rabbitmq_user amqp-kzbe8p3n#keep-user
+```
 */
 package tencentcloud
 
@@ -36,6 +46,13 @@ func resourceTencentCloudTdmqRabbitmqUser() *schema.Resource {
 		Update: resourceTencentCloudTdmqRabbitmqUserUpdate,
 		Delete: resourceTencentCloudTdmqRabbitmqUserDelete,
 
+		CustomizeDiff: resourceTencentCloudTdmqRabbitmqUserLimitDiff,
+
+		// password, max_connections and max_channels are not returned by the API, so they are not imported
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"instance_id": {
 				Required:    true,
@@ -51,7 +68,7 @@ func resourceTencentCloudTdmqRabbitmqUser() *schema.Resource {
 				Required:    true,
 				Type:        schema.TypeString,
 				Sensitive:   true,
-				Description: "Password, used when logging in.",
+				Description: "Password, used when logging in. It can be changed without recreating the user.",
 			},
 			"description": {
 				Optional:    true,
@@ -65,14 +82,16 @@ func resourceTencentCloudTdmqRabbitmqUser() *schema.Resource {
 				Description: "User tag, used to determine the permission range for changing user access to RabbitMQ Management. Management: regular console user, monitoring: management console user, other values: non console user.",
 			},
 			"max_connections": {
-				Optional:    true,
-				Type:        schema.TypeInt,
-				Description: "The maximum number of connections for this user, if not filled in, there is no limit.",
+				Optional:     true,
+				Type:         schema.TypeInt,
+				ValidateFunc: validateIntegerMin(1),
+				Description:  "The maximum number of connections for this user, if not filled in, there is no limit. `0` is invalid, omit it for unlimited connections. Once set, the limit can not be removed, set a higher limit instead.",
 			},
 			"max_channels": {
-				Optional:    true,
-				Type:        schema.TypeInt,
-				Description: "The maximum number of channels for this user, if not filled in, there is no limit.",
+				Optional:     true,
+				Type:         schema.TypeInt,
+				ValidateFunc: validateIntegerMin(1),
+				Description:  "The maximum number of channels for this user, if not filled in, there is no limit. `0` is invalid, omit it for unlimited channels. Once set, the limit can not be removed, set a higher limit instead.",
 			},
 		},
 	}
@@ -111,13 +130,9 @@ func resourceTencentCloudTdmqRabbitmqUserCreate(d *schema.ResourceData, meta int
 		request.Tags = helper.InterfacesStringsPoint(v.([]interface{}))
 	}
 
-	if v, ok := d.GetOkExists("max_connections"); ok {
-		request.MaxConnections = helper.IntInt64(v.(int))
-	}
+	request.MaxConnections = getTdmqRabbitmqUserLimit(d, "max_connections")
 
-	if v, ok := d.GetOkExists("max_channels"); ok {
-		request.MaxChannels = helper.IntInt64(v.(int))
-	}
+	request.MaxChannels = getTdmqRabbitmqUserLimit(d, "max_channels")
 
 	err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
 		result, e := meta.(*TencentCloudClient).apiV3Conn.UseTdmqClient().CreateRabbitMQUser(request)
@@ -140,22 +155,29 @@ func resourceTencentCloudTdmqRabbitmqUserCreate(d *schema.ResourceData, meta int
 
 	d.SetId(strings.Join([]string{instanceId, user}, FILED_SP))
 
-	return resourceTencentCloudTdmqRabbitmqUserRead(d, meta)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+	ctx = context.WithValue(ctx, justCreatedKey, true)
+	return resourceTencentCloudTdmqRabbitmqUserReadWithContext(ctx, d, meta)
 }
 
 func resourceTencentCloudTdmqRabbitmqUserRead(d *schema.ResourceData, meta interface{}) error {
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+	return resourceTencentCloudTdmqRabbitmqUserReadWithContext(ctx, d, meta)
+}
+
+func resourceTencentCloudTdmqRabbitmqUserReadWithContext(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_tdmq_rabbitmq_user.read")()
 	defer inconsistentCheck(d, meta)()
 
 	var (
-		logId   = getLogId(contextNil)
-		ctx     = context.WithValue(context.TODO(), logIdKey, logId)
+		logId   = getLogId(ctx)
 		service = TdmqService{client: meta.(*TencentCloudClient).apiV3Conn}
 	)
 
-	idSplit := strings.Split(d.Id(), FILED_SP)
-	if len(idSplit) != 2 {
-		return fmt.Errorf("id is broken,%s", idSplit)
+	idSplit, err := helper.ParseCompositeId(d.Id(), 2, "instanceId", "user")
+	if err != nil {
+		return err
 	}
 
 	instanceId := idSplit[0]
@@ -180,10 +202,7 @@ func resourceTencentCloudTdmqRabbitmqUserRead(d *schema.ResourceData, meta inter
 		_ = d.Set("user", rabbitmqUser.User)
 	}
 
-	if rabbitmqUser.Password != nil {
-		_ = d.Set("password", rabbitmqUser.Password)
-	}
-
+	// the API does not return the plaintext password, keep the one in state
 	if rabbitmqUser.Description != nil {
 		_ = d.Set("description", rabbitmqUser.Description)
 	}
@@ -204,15 +223,15 @@ func resourceTencentCloudTdmqRabbitmqUserUpdate(d *schema.ResourceData, meta int
 		request = tdmq.NewModifyRabbitMQUserRequest()
 	)
 
-	idSplit := strings.Split(d.Id(), FILED_SP)
-	if len(idSplit) != 2 {
-		return fmt.Errorf("id is broken,%s", idSplit)
+	idSplit, err := helper.ParseCompositeId(d.Id(), 2, "instanceId", "user")
+	if err != nil {
+		return err
 	}
 
 	instanceId := idSplit[0]
 	user := idSplit[1]
 
-	immutableArgs := []string{"instance_id", "user", "password"}
+	immutableArgs := []string{"instance_id", "user"}
 
 	for _, v := range immutableArgs {
 		if d.HasChange(v) {
@@ -220,7 +239,7 @@ func resourceTencentCloudTdmqRabbitmqUserUpdate(d *schema.ResourceData, meta int
 		}
 	}
 
-	if d.HasChange("description") || d.HasChange("max_connections") || d.HasChange("max_channels") {
+	if d.HasChange("password") || d.HasChange("description") || d.HasChange("max_connections") || d.HasChange("max_channels") {
 		request.InstanceId = &instanceId
 		request.User = &user
 
@@ -232,13 +251,9 @@ func resourceTencentCloudTdmqRabbitmqUserUpdate(d *schema.ResourceData, meta int
 			request.Description = helper.String(v.(string))
 		}
 
-		if v, ok := d.GetOkExists("max_connections"); ok {
-			request.MaxConnections = helper.IntInt64(v.(int))
-		}
+		request.MaxConnections = getTdmqRabbitmqUserLimit(d, "max_connections")
 
-		if v, ok := d.GetOkExists("max_channels"); ok {
-			request.MaxChannels = helper.IntInt64(v.(int))
-		}
+		request.MaxChannels = getTdmqRabbitmqUserLimit(d, "max_channels")
 
 		err := resource.Retry(writeRetryTimeout, func() *resource.RetryError {
 			result, e := meta.(*TencentCloudClient).apiV3Conn.UseTdmqClient().ModifyRabbitMQUser(request)
@@ -270,9 +285,9 @@ func resourceTencentCloudTdmqRabbitmqUserDelete(d *schema.ResourceData, meta int
 		service = TdmqService{client: meta.(*TencentCloudClient).apiV3Conn}
 	)
 
-	idSplit := strings.Split(d.Id(), FILED_SP)
-	if len(idSplit) != 2 {
-		return fmt.Errorf("id is broken,%s", idSplit)
+	idSplit, err := helper.ParseCompositeId(d.Id(), 2, "instanceId", "user")
+	if err != nil {
+		return err
 	}
 
 	instanceId := idSplit[0]
@@ -284,3 +299,38 @@ func resourceTencentCloudTdmqRabbitmqUserDelete(d *schema.ResourceData, meta int
 
 	return nil
 }
+
+// getTdmqRabbitmqUserLimit returns nil when the limit is not set, so that the API applies no limit.
+// Limits are at least 1, a zero value means the limit is unset or removed from the config.
+func getTdmqRabbitmqUserLimit(d *schema.ResourceData, key string) *int64 {
+	if v, ok := d.GetOk(key); ok {
+		return helper.IntInt64(v.(int))
+	}
+	return nil
+}
+
+// resourceTencentCloudTdmqRabbitmqUserLimitDiff rejects the removal of a limit at plan time. ModifyRabbitMQUser keeps
+// the current limit when a limit is omitted and has no value for unlimited, so a limit can not go back to unlimited
+// in place, and recreating the user would drop its permissions.
+func resourceTencentCloudTdmqRabbitmqUserLimitDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	for _, key := range []string{"max_connections", "max_channels"} {
+		if tdmqRabbitmqUserLimitRemoved(d, key) {
+			o, _ := d.GetChange(key)
+			return fmt.Errorf("`%s` can not be removed once set, the API has no value for unlimited. Set a limit higher than %d instead", key, o.(int))
+		}
+	}
+	return nil
+}
+
+// tdmqRabbitmqUserLimitRemoved reports whether a limit set before is removed from the config,
+// a limit which stays unset or is only known after apply is not a removal.
+func tdmqRabbitmqUserLimitRemoved(d *schema.ResourceDiff, key string) bool {
+	if !d.HasChange(key) || !d.NewValueKnown(key) {
+		return false
+	}
+	_, ok := d.GetOk(key)
+	return !ok
+}
@@ -1,9 +1,15 @@
 package tencentcloud
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	tdmq "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/tdmq/v20200217"
+	"github.com/tencentcloudstack/terraform-provider-tencentcloud/tencentcloud/internal/helper"
 )
 
 // go test -i; go test -test.run TestAccTencentCloudNeedFixTdmqRabbitmqUserResource_basic -v
@@ -33,14 +39,124 @@ func TestAccTencentCloudNeedFixTdmqRabbitmqUserResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet("tencentcloud_tdmq_rabbitmq_user.rabbitmq_user", "id"),
 					resource.TestCheckResourceAttrSet("tencentcloud_tdmq_rabbitmq_user.rabbitmq_user", "instance_id"),
 					resource.TestCheckResourceAttrSet("tencentcloud_tdmq_rabbitmq_user.rabbitmq_user", "user"),
-					resource.TestCheckResourceAttrSet("tencentcloud_tdmq_rabbitmq_user.rabbitmq_user", "password"),
+					resource.TestCheckResourceAttr("tencentcloud_tdmq_rabbitmq_user.rabbitmq_user", "password", "asdf12345"),
 					resource.TestCheckResourceAttrSet("tencentcloud_tdmq_rabbitmq_user.rabbitmq_user", "description"),
 					resource.TestCheckResourceAttrSet("tencentcloud_tdmq_rabbitmq_user.rabbitmq_user", "max_connections"),
 					resource.TestCheckResourceAttrSet("tencentcloud_tdmq_rabbitmq_user.rabbitmq_user", "max_channels"),
 				),
 			},
+			{
+				ResourceName:            "tencentcloud_tdmq_rabbitmq_user.rabbitmq_user",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "max_connections", "max_channels"},
+			},
+		},
+	})
+}
+
+func TestTdmqRabbitmqUserMaxConnections(t *testing.T) {
+	userSchema := resourceTencentCloudTdmqRabbitmqUser().Schema
+	raw := map[string]interface{}{
+		"instance_id": "amqp-xxxxxxxx",
+		"user":        "keep-user",
+		"password":    "asdf1234",
+	}
+
+	d := schema.TestResourceDataRaw(t, userSchema, raw)
+	if v := getTdmqRabbitmqUserLimit(d, "max_connections"); v != nil {
+		t.Errorf("max_connections: expected nil when unset, got %d", *v)
+	}
+
+	raw["max_connections"] = 3
+	d = schema.TestResourceDataRaw(t, userSchema, raw)
+	if v := getTdmqRabbitmqUserLimit(d, "max_connections"); v == nil || *v != 3 {
+		t.Errorf("max_connections: expected 3, got %v", v)
+	}
+
+	if _, errs := userSchema["max_connections"].ValidateFunc(0, "max_connections"); len(errs) == 0 {
+		t.Errorf("max_connections: expected 0 to be rejected")
+	}
+}
+
+func TestTdmqRabbitmqUserLimitRemoved(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "amqp-xxxxxxxx#keep-user",
+		Attributes: map[string]string{
+			"id":           "amqp-xxxxxxxx#keep-user",
+			"instance_id":  "amqp-xxxxxxxx",
+			"user":         "keep-user",
+			"password":     "asdf1234",
+			"max_channels": "3",
 		},
+	}
+	cases := []struct {
+		name   string
+		raw    map[string]interface{}
+		reject bool
+	}{
+		{"removed", map[string]interface{}{}, true},
+		{"raised", map[string]interface{}{"max_channels": 5}, false},
+		{"unchanged", map[string]interface{}{"max_channels": 3, "description": "test user"}, false},
+		{"unset", map[string]interface{}{"max_channels": 3, "max_connections": 10}, false},
+	}
+	for _, c := range cases {
+		raw := map[string]interface{}{
+			"instance_id": "amqp-xxxxxxxx",
+			"user":        "keep-user",
+			"password":    "asdf1234",
+		}
+		for k, v := range c.raw {
+			raw[k] = v
+		}
+		// the removal must fail the plan, neither fail at apply time nor recreate the user
+		diff, err := resourceTencentCloudTdmqRabbitmqUser().Diff(context.TODO(), state, terraform.NewResourceConfigRaw(raw), nil)
+		if (err != nil) != c.reject {
+			t.Errorf("%s: expected reject %v, got %v", c.name, c.reject, err)
+		}
+		if diff != nil && diff.RequiresNew() {
+			t.Errorf("%s: expected the user not to be recreated", c.name)
+		}
+	}
+}
+
+func TestDescribeTdmqRabbitmqUserInCreateWindow(t *testing.T) {
+	window := 3 * time.Second
+	justCreated := context.WithValue(context.TODO(), justCreatedKey, true)
+
+	// within the window not-found is retried until the user shows up
+	calls := 0
+	user, err := describeTdmqRabbitmqUserInCreateWindow(justCreated, window, func() ([]*tdmq.RabbitMQUser, error) {
+		calls++
+		if calls < 2 {
+			return nil, nil
+		}
+		return []*tdmq.RabbitMQUser{{User: helper.String("keep-user")}}, nil
 	})
+	if err != nil || user == nil || *user.User != "keep-user" || calls != 2 {
+		t.Errorf("within the window: expected the user after 2 calls, got %v, %v after %d calls", user, err, calls)
+	}
+
+	// outside the window the user is still not found, that is a genuine not-found
+	calls = 0
+	user, err = describeTdmqRabbitmqUserInCreateWindow(justCreated, window, func() ([]*tdmq.RabbitMQUser, error) {
+		calls++
+		return nil, nil
+	})
+	if err != nil || user != nil || calls < 2 {
+		t.Errorf("outside the window: expected nil after retrying, got %v, %v after %d calls", user, err, calls)
+	}
+
+	// a read which does not follow a create never waits
+	calls = 0
+	start := time.Now()
+	user, err = describeTdmqRabbitmqUserInCreateWindow(context.TODO(), window, func() ([]*tdmq.RabbitMQUser, error) {
+		calls++
+		return nil, nil
+	})
+	if err != nil || user != nil || calls != 1 || time.Since(start) >= window {
+		t.Errorf("without justCreatedKey: expected nil at once, got %v, %v after %d calls in %s", user, err, calls, time.Since(start))
+	}
 }
 
 const testAccTdmqRabbitmqUser = `
@@ -59,7 +175,7 @@ const testAccTdmqRabbitmqUserUpdate = `
 resource "tencentcloud_tdmq_rabbitmq_user" "rabbitmq_user" {
   instance_id     = "amqp-kzbe8p3n"
   user            = "keep-user"
-  password        = "asdf1234"
+  password        = "asdf12345"
   description     = "test user update"
   tags            = ["management", "monitoring"]
   max_connections = 10
@@ -5,23 +5,21 @@ Example Usage
 
 ```hcl
 resource "tencentcloud_tse_cngw_route" "cngw_route" {
-  gateway_id = "gateway-xxxxxx"
-  service_id = "451a9920-e67a-4519-af41-fccac0e72005"
-  route_name = "routeA"
-  methods =
-  hosts =
-  paths =
-  protocols =
-  preserve_host = true
-  https_redirect_status_code = 302
-  strip_path = true
-  force_https =
-  destination_ports =
-  headers {
-		key = "token"
-		value = "xxxxxx"
+  gateway_id                 = "gateway-xxxxxx"
+  service_id                 = "451a9920-e67a-4519-af41-fccac0e72005"
+  route_name                 = "routeA"
+  methods                    = ["GET", "POST"]
+  paths                      = ["/user"]
+  protocols                  = ["http", "https"]
+  preserve_host              = false
+  https_redirect_status_code = 426
+  strip_path                 = true
 
+  headers {
+    key   = "req"
+    value = "terraform"
   }
+
   tags = {
     "createdBy" = "terraform"
   }
@@ -33,7 +31,13 @@ Import
 tse cngw_route can be imported using the id, e.g.
 
 ```
-terraform import tencentcloud_tse_cngw_route.cngw_route cngw_route_id
+terraform import tencentcloud_tse_cngw_route.cngw_route gatewayId#serviceId#routeName
+```
+
+It can also be imported using the gateway id and the route id, e.g.
+
+```
+terraform import tencentcloud_tse_cngw_route.cngw_route gatewayId#routeId
 ```
 */
 package tencentcloud
@@ -57,8 +61,9 @@ func resourceTencentCloudTseCngwRoute() *schema.Resource {
 		Update: resourceTencentCloudTseCngwRouteUpdate,
 		Delete: resourceTencentCloudTseCngwRouteDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceTencentCloudTseCngwRouteImport,
 		},
+		CustomizeDiff: resourceTencentCloudTseCngwRouteHeadersDiff,
 		Schema: map[string]*schema.Schema{
 			"gateway_id": {
 				Required:    true,
@@ -150,7 +155,8 @@ func resourceTencentCloudTseCngwRoute() *schema.Resource {
 			"headers": {
 				Optional:    true,
 				Type:        schema.TypeList,
-				Description: "the headers of route.",
+				MaxItems:    1,
+				Description: "the headers of route. At most one header is supported, since only one can be read back from the API. Removing the header replaces the route.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"key": {
@@ -308,6 +314,46 @@ func resourceTencentCloudTseCngwRouteCreate(d *schema.ResourceData, meta interfa
 	return resourceTencentCloudTseCngwRouteRead(d, meta)
 }
 
+// resourceTencentCloudTseCngwRouteImport accepts `gatewayId#serviceId#routeName`, or `gatewayId#routeId`
+// which is resolved to the former by listing the routes of the gateway.
+func resourceTencentCloudTseCngwRouteImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	idSplit := strings.Split(d.Id(), FILED_SP)
+	if len(idSplit) == 3 {
+		return []*schema.ResourceData{d}, nil
+	}
+	if len(idSplit) != 2 || idSplit[0] == "" || idSplit[1] == "" {
+		return nil, fmt.Errorf("id `%s` is broken, expected format is `gatewayId#serviceId#routeName` or `gatewayId#routeId`", d.Id())
+	}
+	gatewayId := idSplit[0]
+	routeId := idSplit[1]
+
+	logId := getLogId(contextNil)
+	ctx := context.WithValue(context.TODO(), logIdKey, logId)
+	service := TseService{client: meta.(*TencentCloudClient).apiV3Conn}
+
+	var routes *tse.KongServiceRouteList
+	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		result, e := service.DescribeTseGatewayRoutesByFilter(ctx, map[string]interface{}{"GatewayId": helper.String(gatewayId)})
+		if e != nil {
+			return retryError(e)
+		}
+		routes = result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, route := range routes.RouteList {
+		if route.ID == nil || *route.ID != routeId || route.ServiceID == nil || route.Name == nil {
+			continue
+		}
+		d.SetId(gatewayId + FILED_SP + *route.ServiceID + FILED_SP + *route.Name)
+		return []*schema.ResourceData{d}, nil
+	}
+	return nil, fmt.Errorf("route `%s` is not found in gateway %s", routeId, gatewayId)
+}
+
 func resourceTencentCloudTseCngwRouteRead(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_tse_cngw_route.read")()
 	defer inconsistentCheck(d, meta)()
@@ -377,25 +423,22 @@ func resourceTencentCloudTseCngwRouteRead(d *schema.ResourceData, meta interface
 		_ = d.Set("destination_ports", cngwRoute.DestinationPorts)
 	}
 
-	// if cngwRoute.Headers != nil {
-	// 	headersList := []interface{}{}
-	// 	for _, headers := range cngwRoute.Headers {
-	// 		headersMap := map[string]interface{}{}
+	// always set headers so that clearing them remotely is detected
+	headersList := []interface{}{}
+	if cngwRoute.Headers != nil {
+		headersMap := map[string]interface{}{}
 
-	// 		if cngwRoute.Headers.Key != nil {
-	// 			headersMap["key"] = cngwRoute.Headers.Key
-	// 		}
-
-	// 		if cngwRoute.Headers.Value != nil {
-	// 			headersMap["value"] = cngwRoute.Headers.Value
-	// 		}
-
-	// 		headersList = append(headersList, headersMap)
-	// 	}
+		if cngwRoute.Headers.Key != nil {
+			headersMap["key"] = cngwRoute.Headers.Key
+		}
 
-	// 	_ = d.Set("headers", headersList)
+		if cngwRoute.Headers.Value != nil {
+			headersMap["value"] = cngwRoute.Headers.Value
+		}
 
-	// }
+		headersList = append(headersList, headersMap)
+	}
+	_ = d.Set("headers", headersList)
 
 	if cngwRoute.ID != nil {
 		_ = d.Set("route_id", cngwRoute.ID)
@@ -513,19 +556,18 @@ func resourceTencentCloudTseCngwRouteUpdate(d *schema.ResourceData, meta interfa
 		}
 	}
 
-	if d.HasChange("headers") {
-		if v, ok := d.GetOk("headers"); ok {
-			for _, item := range v.([]interface{}) {
-				dMap := item.(map[string]interface{})
-				kVMapping := tse.KVMapping{}
-				if v, ok := dMap["key"]; ok {
-					kVMapping.Key = helper.String(v.(string))
-				}
-				if v, ok := dMap["value"]; ok {
-					kVMapping.Value = helper.String(v.(string))
-				}
-				request.Headers = append(request.Headers, &kVMapping)
+	// headers are always sent, otherwise modifying other arguments drops them
+	if v, ok := d.GetOk("headers"); ok {
+		for _, item := range v.([]interface{}) {
+			dMap := item.(map[string]interface{})
+			kVMapping := tse.KVMapping{}
+			if v, ok := dMap["key"]; ok {
+				kVMapping.Key = helper.String(v.(string))
+			}
+			if v, ok := dMap["value"]; ok {
+				kVMapping.Value = helper.String(v.(string))
 			}
+			request.Headers = append(request.Headers, &kVMapping)
 		}
 	}
 
@@ -558,6 +600,18 @@ func resourceTencentCloudTseCngwRouteUpdate(d *schema.ResourceData, meta interfa
 	return resourceTencentCloudTseCngwRouteRead(d, meta)
 }
 
+// the modify API omits empty headers, so removing all of them replaces the route
+func resourceTencentCloudTseCngwRouteHeadersDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("headers") {
+		return nil
+	}
+	o, n := d.GetChange("headers")
+	if len(o.([]interface{})) > 0 && len(n.([]interface{})) == 0 {
+		return d.ForceNew("headers")
+	}
+	return nil
+}
+
 func resourceTencentCloudTseCngwRouteDelete(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_tse_cngw_route.delete")()
 	defer inconsistentCheck(d, meta)()
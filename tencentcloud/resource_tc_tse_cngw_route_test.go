@@ -1,9 +1,13 @@
 package tencentcloud
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccTencentCloudNeedFixTseCngwRouteResource_basic(t *testing.T) {
@@ -16,40 +20,250 @@ func TestAccTencentCloudNeedFixTseCngwRouteResource_basic(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: testAccTseCngwRoute,
-				Check:  resource.ComposeTestCheckFunc(resource.TestCheckResourceAttrSet("tencentcloud_tse_cngw_route.cngw_route", "id")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_tse_cngw_route.cngw_route", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_tse_cngw_route.cngw_route", "headers.#", "1"),
+					resource.TestCheckResourceAttr("tencentcloud_tse_cngw_route.cngw_route", "headers.0.key", "req"),
+					resource.TestCheckResourceAttr("tencentcloud_tse_cngw_route.cngw_route", "headers.0.value", "terraform"),
+				),
 			},
 			{
 				ResourceName:      "tencentcloud_tse_cngw_route.cngw_route",
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName: "tencentcloud_tse_cngw_route.cngw_route",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["tencentcloud_tse_cngw_route.cngw_route"]
+					if !ok {
+						return "", fmt.Errorf("tencentcloud_tse_cngw_route.cngw_route is not found")
+					}
+					return rs.Primary.Attributes["gateway_id"] + FILED_SP + rs.Primary.Attributes["route_id"], nil
+				},
+				ImportStateVerify: true,
+			},
+			{
+				Config:   testAccTseCngwRouteReordered,
+				PlanOnly: true,
+			},
+			{
+				Config: testAccTseCngwRouteUpHeaders,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_tse_cngw_route.cngw_route", "headers.#", "1"),
+					resource.TestCheckResourceAttr("tencentcloud_tse_cngw_route.cngw_route", "headers.0.key", "req"),
+					resource.TestCheckResourceAttr("tencentcloud_tse_cngw_route.cngw_route", "headers.0.value", "terraform-up"),
+				),
+			},
+			{
+				// removing all headers replaces the route
+				Config: testAccTseCngwRouteNoHeaders,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_tse_cngw_route.cngw_route", "headers.#", "0"),
+				),
+			},
 		},
 	})
 }
 
-const testAccTseCngwRoute = `
+func TestTseCngwRouteUnorderedLists(t *testing.T) {
+	routeSchema := resourceTencentCloudTseCngwRoute().Schema
+	base := map[string]interface{}{
+		"gateway_id": "gateway-xxxxxxxx",
+		"service_id": "service-xxxxxxxx",
+		"route_name": "terraform-route",
+	}
+	lists := map[string][2][]interface{}{
+		"methods":   {{"GET", "POST"}, {"POST", "GET", "POST"}},
+		"hosts":     {{"a.example.com", "b.example.com"}, {"b.example.com", "a.example.com"}},
+		"paths":     {{"/user", "/order"}, {"/order", "/user", "/user"}},
+		"protocols": {{"http", "https"}, {"https", "http"}},
+	}
+
+	for k, v := range lists {
+		raw := map[string]interface{}{}
+		for bk, bv := range base {
+			raw[bk] = bv
+		}
+		raw[k] = v[0]
+		before := schema.TestResourceDataRaw(t, routeSchema, raw).Get(k).(*schema.Set)
+		raw[k] = v[1]
+		after := schema.TestResourceDataRaw(t, routeSchema, raw).Get(k).(*schema.Set)
+		if !before.Equal(after) {
+			t.Errorf("%s: expected %v to equal %v regardless of order and duplicates", k, before.List(), after.List())
+		}
+	}
+}
+
+func TestTseCngwRouteHeadersMaxItems(t *testing.T) {
+	raw := map[string]interface{}{
+		"gateway_id": "gateway-xxxxxxxx",
+		"service_id": "service-xxxxxxxx",
+		"route_name": "terraform-route",
+		"headers": []interface{}{
+			map[string]interface{}{"key": "req", "value": "terraform"},
+		},
+	}
+	if diags := resourceTencentCloudTseCngwRoute().Validate(terraform.NewResourceConfigRaw(raw)); diags.HasError() {
+		t.Errorf("expected one header to be accepted, got %v", diags)
+	}
+
+	// the API returns a single header, more would never converge
+	raw["headers"] = append(raw["headers"].([]interface{}), map[string]interface{}{"key": "env", "value": "test"})
+	if diags := resourceTencentCloudTseCngwRoute().Validate(terraform.NewResourceConfigRaw(raw)); !diags.HasError() {
+		t.Errorf("expected two headers to be rejected")
+	}
+}
+
+func TestTseCngwRouteHeadersDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "gateway-xxxxxxxx#service-xxxxxxxx#terraform-route",
+		Attributes: map[string]string{
+			"id":              "gateway-xxxxxxxx#service-xxxxxxxx#terraform-route",
+			"gateway_id":      "gateway-xxxxxxxx",
+			"service_id":      "service-xxxxxxxx",
+			"route_name":      "terraform-route",
+			"headers.#":       "1",
+			"headers.0.key":   "req",
+			"headers.0.value": "terraform",
+		},
+	}
+	base := map[string]interface{}{
+		"gateway_id": "gateway-xxxxxxxx",
+		"service_id": "service-xxxxxxxx",
+		"route_name": "terraform-route",
+	}
+
+	cases := map[string]struct {
+		headers     []interface{}
+		requiresNew bool
+	}{
+		"modified": {[]interface{}{map[string]interface{}{"key": "req", "value": "terraform-up"}}, false},
+		"removed":  {nil, true},
+	}
+
+	for name, c := range cases {
+		raw := map[string]interface{}{}
+		for k, v := range base {
+			raw[k] = v
+		}
+		if c.headers != nil {
+			raw["headers"] = c.headers
+		}
+		diff, err := resourceTencentCloudTseCngwRoute().Diff(context.TODO(), state, terraform.NewResourceConfigRaw(raw), nil)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if diff == nil {
+			t.Fatalf("%s: expected a diff", name)
+		}
+		if diff.RequiresNew() != c.requiresNew {
+			t.Errorf("%s: expected requires new %v, got %v", name, c.requiresNew, diff.RequiresNew())
+		}
+	}
+}
+
+const testAccTseCngwRouteService = DefaultTseVar + `
+
+resource "tencentcloud_tse_cngw_service" "cngw_service" {
+  gateway_id    = var.gateway_id
+  name          = "terraform-test-route"
+  path          = "/test"
+  protocol      = "http"
+  retries       = 5
+  timeout       = 60000
+  upstream_type = "IPList"
+
+  upstream_info {
+    algorithm  = "round-robin"
+    port       = 0
+    slow_start = 20
+
+    targets {
+      host   = "192.168.0.1"
+      port   = 80
+      weight = 100
+    }
+  }
+}
+`
+
+const testAccTseCngwRoute = testAccTseCngwRouteService + `
 
 resource "tencentcloud_tse_cngw_route" "cngw_route" {
-  gateway_id = "gateway-xxxxxx"
-  service_i_d = "451a9920-e67a-4519-af41-fccac0e72005"
-  route_name = "routeA"
-  methods = 
-  hosts = 
-  paths = 
-  protocols = 
-  preserve_host = true
-  https_redirect_status_code = 302
-  strip_path = true
-  force_https = 
-  destination_ports = 
+  gateway_id                 = var.gateway_id
+  service_id                 = tencentcloud_tse_cngw_service.cngw_service.service_id
+  route_name                 = "terraform-route"
+  methods                    = ["GET", "POST"]
+  paths                      = ["/user"]
+  protocols                  = ["http", "https"]
+  preserve_host              = false
+  https_redirect_status_code = 426
+  strip_path                 = true
+
   headers {
-		key = "token"
-		value = "xxxxxx"
+    key   = "req"
+    value = "terraform"
+  }
+}
+
+`
 
+const testAccTseCngwRouteReordered = testAccTseCngwRouteService + `
+
+resource "tencentcloud_tse_cngw_route" "cngw_route" {
+  gateway_id                 = var.gateway_id
+  service_id                 = tencentcloud_tse_cngw_service.cngw_service.service_id
+  route_name                 = "terraform-route"
+  methods                    = ["POST", "GET"]
+  paths                      = ["/user", "/user"]
+  protocols                  = ["https", "http"]
+  preserve_host              = false
+  https_redirect_status_code = 426
+  strip_path                 = true
+
+  headers {
+    key   = "req"
+    value = "terraform"
   }
-  tags = {
-    "createdBy" = "terraform"
+}
+
+`
+
+const testAccTseCngwRouteUpHeaders = testAccTseCngwRouteService + `
+
+resource "tencentcloud_tse_cngw_route" "cngw_route" {
+  gateway_id                 = var.gateway_id
+  service_id                 = tencentcloud_tse_cngw_service.cngw_service.service_id
+  route_name                 = "terraform-route"
+  methods                    = ["GET", "POST"]
+  paths                      = ["/user"]
+  protocols                  = ["http", "https"]
+  preserve_host              = false
+  https_redirect_status_code = 426
+  strip_path                 = true
+
+  headers {
+    key   = "req"
+    value = "terraform-up"
   }
 }
 
 `
+
+const testAccTseCngwRouteNoHeaders = testAccTseCngwRouteService + `
+
+resource "tencentcloud_tse_cngw_route" "cngw_route" {
+  gateway_id                 = var.gateway_id
+  service_id                 = tencentcloud_tse_cngw_service.cngw_service.service_id
+  route_name                 = "terraform-route"
+  methods                    = ["GET", "POST"]
+  paths                      = ["/user"]
+  protocols                  = ["http", "https"]
+  preserve_host              = false
+  https_redirect_status_code = 426
+  strip_path                 = true
+}
+
+`
@@ -140,7 +140,7 @@ func resourceTencentCloudVpcResumeSnapshotInstanceCreate(d *schema.ResourceData,
 		return err
 	}
 
-	d.SetId(snapshotPolicyId + FILED_SP + snapshotFileId + FILED_SP + instanceId)
+	d.SetId(helper.IdFormat(snapshotPolicyId, snapshotFileId, instanceId))
 
 	return resourceTencentCloudVpcResumeSnapshotInstanceRead(d, meta)
 }
@@ -149,6 +149,17 @@ func resourceTencentCloudVpcResumeSnapshotInstanceRead(d *schema.ResourceData, m
 	defer logElapsed("resource.tencentcloud_vpc_resume_snapshot_instance.read")()
 	defer inconsistentCheck(d, meta)()
 
+	// resuming a snapshot is a one-shot operation, there is nothing to query,
+	// keep the resource and restore its arguments from the id
+	ids, err := helper.ParseCompositeId(d.Id(), 3, "snapshotPolicyId", "snapshotFileId", "instanceId")
+	if err != nil {
+		return err
+	}
+
+	_ = d.Set("snapshot_policy_id", ids[0])
+	_ = d.Set("snapshot_file_id", ids[1])
+	_ = d.Set("instance_id", ids[2])
+
 	return nil
 }
 
@@ -16,12 +16,14 @@ func TestAccTencentCloudNeedFixVpcResumeSnapshotInstanceResource_basic(t *testin
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVpcResumeSnapshotInstance,
-				Check:  resource.ComposeTestCheckFunc(resource.TestCheckResourceAttrSet("tencentcloud_vpc_resume_snapshot_instance.resume_snapshot_instance", "id")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_vpc_resume_snapshot_instance.resume_snapshot_instance", "id", "sspolicy-1t6cobbv#ssfile-test#policy-1t6cob"),
+				),
 			},
 			{
-				ResourceName:      "tencentcloud_vpc_resume_snapshot_instance.resume_snapshot_instance",
-				ImportState:       true,
-				ImportStateVerify: true,
+				// re-applying the same config must not trigger another resume
+				Config:   testAccVpcResumeSnapshotInstance,
+				PlanOnly: true,
 			},
 		},
 	})
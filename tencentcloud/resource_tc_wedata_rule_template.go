@@ -30,8 +30,10 @@ package tencentcloud
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"log"
+	"unicode/utf8"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -48,6 +50,7 @@ func resourceTencentCloudWedataRuleTemplate() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceTencentCloudWedataRuleTemplateSqlExpressionDiff,
 		Schema: map[string]*schema.Schema{
 			"type": {
 				Optional:    true,
@@ -95,15 +98,24 @@ func resourceTencentCloudWedataRuleTemplate() *schema.Resource {
 			},
 
 			"sql_expression": {
-				Optional:    true,
-				Type:        schema.TypeString,
-				Description: "SQL Expression.",
+				Optional:         true,
+				Type:             schema.TypeString,
+				DiffSuppressFunc: wedataSqlExpressionDiffSuppress,
+				Description:      "SQL Expression, encoded in base64 unless `sql_expression_encoding` is `plaintext`.",
+			},
+
+			"sql_expression_encoding": {
+				Optional:     true,
+				Type:         schema.TypeString,
+				Default:      WEDATA_SQL_EXPRESSION_ENCODING_BASE64,
+				ValidateFunc: validateAllowedStringValue(WEDATA_SQL_EXPRESSION_ENCODINGS),
+				Description:  "Encoding of `sql_expression`. Valid values: `base64`, `plaintext`. When it is `plaintext`, the expression is encoded in base64 before it is sent, and decoded when it is read back. Default is `base64`.",
 			},
 
 			"project_id": {
 				Optional:    true,
 				Type:        schema.TypeString,
-				Description: "Project ID.",
+				Description: "Project ID. It can not be read back from the API, so it is left empty after import.",
 			},
 
 			"where_flag": {
@@ -159,7 +171,7 @@ func resourceTencentCloudWedataRuleTemplateCreate(d *schema.ResourceData, meta i
 	}
 
 	if v, ok := d.GetOk("sql_expression"); ok {
-		request.SqlExpression = helper.String(v.(string))
+		request.SqlExpression = helper.String(wedataEncodeSqlExpression(v.(string), d.Get("sql_expression_encoding").(string)))
 	}
 
 	if v, ok := d.GetOk("project_id"); ok {
@@ -185,10 +197,20 @@ func resourceTencentCloudWedataRuleTemplateCreate(d *schema.ResourceData, meta i
 		return err
 	}
 
+	if response == nil || response.Response == nil || response.Response.Data == nil || *response.Response.Data == 0 {
+		return fmt.Errorf("create wedata ruleTemplate failed, the returned template id is invalid")
+	}
+
+	// keep the id in state before reading, so a failed read does not orphan the created template
 	ruleTemplateId = *response.Response.Data
 	d.SetId(helper.UInt64ToStr(ruleTemplateId))
 
-	return resourceTencentCloudWedataRuleTemplateRead(d, meta)
+	if err := resourceTencentCloudWedataRuleTemplateRead(d, meta); err != nil {
+		log.Printf("[WARN]%s wedata ruleTemplate [%s] created, but read failed, reason:%+v", logId, d.Id(), err)
+		return err
+	}
+
+	return nil
 }
 
 func resourceTencentCloudWedataRuleTemplateRead(d *schema.ResourceData, meta interface{}) error {
@@ -202,8 +224,10 @@ func resourceTencentCloudWedataRuleTemplateRead(d *schema.ResourceData, meta int
 	service := WedataService{client: meta.(*TencentCloudClient).apiV3Conn}
 
 	ruleTemplateId := d.Id()
+	// project_id is not returned by the API, keep the one in state and query with it
+	projectId := d.Get("project_id").(string)
 
-	ruleTemplate, err := service.DescribeWedataRuleTemplateById(ctx, ruleTemplateId)
+	ruleTemplate, err := service.DescribeWedataRuleTemplateById(ctx, projectId, ruleTemplateId)
 	if err != nil {
 		return err
 	}
@@ -214,6 +238,8 @@ func resourceTencentCloudWedataRuleTemplateRead(d *schema.ResourceData, meta int
 		return nil
 	}
 
+	_ = d.Set("project_id", projectId)
+
 	if ruleTemplate.Type != nil {
 		_ = d.Set("type", ruleTemplate.Type)
 	}
@@ -242,8 +268,17 @@ func resourceTencentCloudWedataRuleTemplateRead(d *schema.ResourceData, meta int
 		_ = d.Set("multi_source_flag", ruleTemplate.MultiSourceFlag)
 	}
 
+	// sql_expression_encoding only affects the provider, it is not returned by the API
+	if _, ok := d.GetOk("sql_expression_encoding"); !ok {
+		_ = d.Set("sql_expression_encoding", WEDATA_SQL_EXPRESSION_ENCODING_BASE64)
+	}
+
 	if ruleTemplate.SqlExpression != nil {
-		_ = d.Set("sql_expression", ruleTemplate.SqlExpression)
+		sqlExpression := *ruleTemplate.SqlExpression
+		if d.Get("sql_expression_encoding").(string) == WEDATA_SQL_EXPRESSION_ENCODING_PLAINTEXT {
+			sqlExpression = wedataDecodeSqlExpression(sqlExpression)
+		}
+		_ = d.Set("sql_expression", sqlExpression)
 	}
 
 	if ruleTemplate.WhereFlag != nil {
@@ -253,6 +288,55 @@ func resourceTencentCloudWedataRuleTemplateRead(d *schema.ResourceData, meta int
 	return nil
 }
 
+// wedataSqlExpressionDiffSuppress compares the decoded sql expressions, so a base64 expression
+// does not drift from the same plain expression returned by the API. It never suppresses while
+// sql_expression_encoding changes, otherwise the old expression would be sent with the new encoding.
+func wedataSqlExpressionDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	if d != nil && d.HasChange("sql_expression_encoding") {
+		return false
+	}
+	decode := func(v string) string {
+		if decoded, err := base64.StdEncoding.DecodeString(v); err == nil {
+			return string(decoded)
+		}
+		return v
+	}
+	return decode(old) == decode(new)
+}
+
+// resourceTencentCloudWedataRuleTemplateSqlExpressionDiff checks a base64 sql_expression, which can not be done
+// by a ValidateFunc since it depends on sql_expression_encoding.
+func resourceTencentCloudWedataRuleTemplateSqlExpressionDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("sql_expression") || !d.NewValueKnown("sql_expression_encoding") {
+		return nil
+	}
+	sqlExpression := d.Get("sql_expression").(string)
+	if sqlExpression == "" || d.Get("sql_expression_encoding").(string) != WEDATA_SQL_EXPRESSION_ENCODING_BASE64 {
+		return nil
+	}
+	if _, err := base64.StdEncoding.DecodeString(sqlExpression); err != nil {
+		return fmt.Errorf("sql_expression is not valid base64, set `sql_expression_encoding = \"plaintext\"` to pass a plain SQL: %s", err.Error())
+	}
+	return nil
+}
+
+// wedataEncodeSqlExpression returns the sql expression in base64, which is what the API expects.
+func wedataEncodeSqlExpression(sqlExpression, encoding string) string {
+	if encoding == WEDATA_SQL_EXPRESSION_ENCODING_PLAINTEXT {
+		return base64.StdEncoding.EncodeToString([]byte(sqlExpression))
+	}
+	return sqlExpression
+}
+
+// wedataDecodeSqlExpression decodes a base64 sql expression, other values are returned as is.
+func wedataDecodeSqlExpression(sqlExpression string) string {
+	decoded, err := base64.StdEncoding.DecodeString(sqlExpression)
+	if err != nil || !utf8.Valid(decoded) {
+		return sqlExpression
+	}
+	return string(decoded)
+}
+
 func resourceTencentCloudWedataRuleTemplateUpdate(d *schema.ResourceData, meta interface{}) error {
 	defer logElapsed("resource.tencentcloud_wedata_rule_template.update")()
 	defer inconsistentCheck(d, meta)()
@@ -264,12 +348,9 @@ func resourceTencentCloudWedataRuleTemplateUpdate(d *schema.ResourceData, meta i
 	ruleTemplateId := d.Id()
 
 	request.TemplateId = helper.StrToUint64Point(ruleTemplateId)
+	request.ProjectId = helper.String(d.Get("project_id").(string))
 
-	immutableArgs := []string{
-		"type", "name", "quality_dim", "source_object_type",
-		"description", "source_engine_types", "multi_source_flag",
-		"sql_expression", "project_id", "where_flag",
-	}
+	immutableArgs := []string{"type", "source_object_type", "project_id"}
 
 	for _, v := range immutableArgs {
 		if d.HasChange(v) {
@@ -277,12 +358,6 @@ func resourceTencentCloudWedataRuleTemplateUpdate(d *schema.ResourceData, meta i
 		}
 	}
 
-	if d.HasChange("type") {
-		if v, ok := d.GetOkExists("type"); ok {
-			request.Type = helper.IntUint64(v.(int))
-		}
-	}
-
 	if d.HasChange("name") {
 		if v, ok := d.GetOk("name"); ok {
 			request.Name = helper.String(v.(string))
@@ -295,12 +370,6 @@ func resourceTencentCloudWedataRuleTemplateUpdate(d *schema.ResourceData, meta i
 		}
 	}
 
-	if d.HasChange("source_object_type") {
-		if v, ok := d.GetOkExists("source_object_type"); ok {
-			request.SourceObjectType = helper.IntUint64(v.(int))
-		}
-	}
-
 	if d.HasChange("description") {
 		if v, ok := d.GetOk("description"); ok {
 			request.Description = helper.String(v.(string))
@@ -323,15 +392,9 @@ func resourceTencentCloudWedataRuleTemplateUpdate(d *schema.ResourceData, meta i
 		}
 	}
 
-	if d.HasChange("sql_expression") {
+	if d.HasChange("sql_expression") || d.HasChange("sql_expression_encoding") {
 		if v, ok := d.GetOk("sql_expression"); ok {
-			request.SqlExpression = helper.String(v.(string))
-		}
-	}
-
-	if d.HasChange("project_id") {
-		if v, ok := d.GetOk("project_id"); ok {
-			request.ProjectId = helper.String(v.(string))
+			request.SqlExpression = helper.String(wedataEncodeSqlExpression(v.(string), d.Get("sql_expression_encoding").(string)))
 		}
 	}
 
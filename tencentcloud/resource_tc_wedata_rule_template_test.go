@@ -1,9 +1,11 @@
 package tencentcloud
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccTencentCloudWedataRuleTemplateResource_basic(t *testing.T) {
@@ -16,17 +18,117 @@ func TestAccTencentCloudWedataRuleTemplateResource_basic(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: testAccWedataRuleTemplate,
-				Check:  resource.ComposeTestCheckFunc(resource.TestCheckResourceAttrSet("tencentcloud_wedata_rule_template.rule_template", "id")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tencentcloud_wedata_rule_template.rule_template", "id"),
+					resource.TestCheckResourceAttr("tencentcloud_wedata_rule_template.rule_template", "type", "2"),
+					resource.TestCheckResourceAttr("tencentcloud_wedata_rule_template.rule_template", "name", "fo test"),
+					resource.TestCheckResourceAttr("tencentcloud_wedata_rule_template.rule_template", "quality_dim", "3"),
+					resource.TestCheckResourceAttr("tencentcloud_wedata_rule_template.rule_template", "source_object_type", "2"),
+					resource.TestCheckResourceAttr("tencentcloud_wedata_rule_template.rule_template", "description", "for tf test"),
+					resource.TestCheckResourceAttr("tencentcloud_wedata_rule_template.rule_template", "source_engine_types.#", "1"),
+					resource.TestCheckResourceAttr("tencentcloud_wedata_rule_template.rule_template", "multi_source_flag", "false"),
+					resource.TestCheckResourceAttr("tencentcloud_wedata_rule_template.rule_template", "sql_expression", "c2VsZWN0ICogZnJvbSBkYg=="),
+					resource.TestCheckResourceAttr("tencentcloud_wedata_rule_template.rule_template", "project_id", "1840731346428280832"),
+					resource.TestCheckResourceAttr("tencentcloud_wedata_rule_template.rule_template", "where_flag", "false"),
+				),
 			},
 			{
-				ResourceName:      "tencentcloud_wedata_rule_template.rule_template",
-				ImportState:       true,
-				ImportStateVerify: true,
+				// refresh and expect no drift
+				Config:   testAccWedataRuleTemplate,
+				PlanOnly: true,
+			},
+			{
+				Config: testAccWedataRuleTemplateUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tencentcloud_wedata_rule_template.rule_template", "name", "fo test update"),
+					resource.TestCheckResourceAttr("tencentcloud_wedata_rule_template.rule_template", "description", "for tf test update"),
+					resource.TestCheckResourceAttr("tencentcloud_wedata_rule_template.rule_template", "where_flag", "true"),
+				),
+			},
+			{
+				ResourceName:            "tencentcloud_wedata_rule_template.rule_template",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"project_id"},
 			},
 		},
 	})
 }
 
+func TestWedataSqlExpressionDiffSuppress(t *testing.T) {
+	cases := []struct {
+		old, new string
+		suppress bool
+	}{
+		{"c2VsZWN0ICogZnJvbSBkYg==", "c2VsZWN0ICogZnJvbSBkYg==", true},
+		{"select * from db", "c2VsZWN0ICogZnJvbSBkYg==", true},
+		{"c2VsZWN0ICogZnJvbSBkYg==", "select * from db", true},
+		{"select * from db", "c2VsZWN0IDEgZnJvbSBkYg==", false},
+		{"", "c2VsZWN0ICogZnJvbSBkYg==", false},
+	}
+	for _, c := range cases {
+		if got := wedataSqlExpressionDiffSuppress("sql_expression", c.old, c.new, nil); got != c.suppress {
+			t.Errorf("old %q new %q: expected suppress %v, got %v", c.old, c.new, c.suppress, got)
+		}
+	}
+}
+
+func TestWedataSqlExpressionEncodingChangeDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"id":                      "1",
+			"sql_expression":          "c2VsZWN0ICogZnJvbSBkYg==",
+			"sql_expression_encoding": WEDATA_SQL_EXPRESSION_ENCODING_BASE64,
+		},
+	}
+
+	// the same SQL in plain form must be planned, or the old base64 value is encoded once more on update
+	raw := map[string]interface{}{
+		"sql_expression":          "select * from db",
+		"sql_expression_encoding": WEDATA_SQL_EXPRESSION_ENCODING_PLAINTEXT,
+	}
+	diff, err := resourceTencentCloudWedataRuleTemplate().Diff(context.TODO(), state, terraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["sql_expression"] == nil {
+		t.Fatalf("expected a diff on sql_expression, got %v", diff)
+	}
+	attr := diff.Attributes["sql_expression"]
+	if attr.New != "select * from db" {
+		t.Errorf("expected the new sql_expression %q, got %q", "select * from db", attr.New)
+	}
+	if got := wedataEncodeSqlExpression(attr.New, WEDATA_SQL_EXPRESSION_ENCODING_PLAINTEXT); got != "c2VsZWN0ICogZnJvbSBkYg==" {
+		t.Errorf("expected the expression to be encoded once, got %q", got)
+	}
+
+	// without an encoding change the plain and base64 forms are still the same expression
+	state.Attributes["sql_expression_encoding"] = WEDATA_SQL_EXPRESSION_ENCODING_PLAINTEXT
+	diff, err = resourceTencentCloudWedataRuleTemplate().Diff(context.TODO(), state, terraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && diff.Attributes["sql_expression"] != nil {
+		t.Errorf("expected no diff on sql_expression, got %v", diff.Attributes["sql_expression"])
+	}
+}
+
+func TestWedataSqlExpressionEncoding(t *testing.T) {
+	if got := wedataEncodeSqlExpression("select * from db", WEDATA_SQL_EXPRESSION_ENCODING_PLAINTEXT); got != "c2VsZWN0ICogZnJvbSBkYg==" {
+		t.Errorf("plaintext expression should be encoded, got %q", got)
+	}
+	if got := wedataEncodeSqlExpression("c2VsZWN0ICogZnJvbSBkYg==", WEDATA_SQL_EXPRESSION_ENCODING_BASE64); got != "c2VsZWN0ICogZnJvbSBkYg==" {
+		t.Errorf("base64 expression should be sent as is, got %q", got)
+	}
+	if got := wedataDecodeSqlExpression("c2VsZWN0ICogZnJvbSBkYg=="); got != "select * from db" {
+		t.Errorf("base64 expression should be decoded, got %q", got)
+	}
+	if got := wedataDecodeSqlExpression("select * from db"); got != "select * from db" {
+		t.Errorf("plain expression should be returned as is, got %q", got)
+	}
+}
+
 const testAccWedataRuleTemplate = `
 
 resource "tencentcloud_wedata_rule_template" "rule_template" {
@@ -43,3 +145,20 @@ resource "tencentcloud_wedata_rule_template" "rule_template" {
 }
 
 `
+
+const testAccWedataRuleTemplateUpdate = `
+
+resource "tencentcloud_wedata_rule_template" "rule_template" {
+  type                = 2
+  name                = "fo test update"
+  quality_dim         = 3
+  source_object_type  = 2
+  description         = "for tf test update"
+  source_engine_types = [3]
+  multi_source_flag   = false
+  sql_expression      = "c2VsZWN0ICogZnJvbSBkYg=="
+  project_id          = "1840731346428280832"
+  where_flag          = true
+}
+
+`
@@ -1662,6 +1662,51 @@ func (me *CosService) BucketGetIntelligentTiering(ctx context.Context, bucket st
 	return
 }
 
+// ListBucketInventoryConfigurations returns the full inventory configurations of the bucket,
+// following the continuation token until the listing is no longer truncated.
+func (me *CosService) ListBucketInventoryConfigurations(ctx context.Context, bucket string) (configurations []cos.BucketListInventoryConfiguartion, errRet error) {
+	logId := getLogId(ctx)
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, reason[%s]\n",
+				logId, "ListInventoryConfigurations", errRet.Error())
+		}
+	}()
+
+	token := ""
+	configurations = make([]cos.BucketListInventoryConfiguartion, 0)
+	for {
+		var result *cos.ListBucketInventoryConfigResult
+		err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+			ratelimit.Check("ListInventoryConfigurations")
+			res, response, e := me.client.UseTencentCosClient(bucket).Bucket.ListInventoryConfigurations(ctx, token)
+			if e != nil {
+				return retryError(e)
+			}
+			resp, _ := json.Marshal(response.Response.Body)
+			log.Printf("[DEBUG]%s api[%s] success, request [%s], response body [%s]\n",
+				logId, "ListInventoryConfigurations", token, resp)
+			result = res
+			return nil
+		})
+		if err != nil {
+			errRet = err
+			return
+		}
+
+		configurations = append(configurations, result.InventoryConfigurations...)
+
+		// stop when the listing is complete, or the token does not move forward
+		if !result.IsTruncated || result.NextContinuationToken == "" || result.NextContinuationToken == token {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+
+	return
+}
+
 /*
 The ideal sequence COS wants.
 Priority 1: permission priority: Read first, then handle WRITE, FullControl, WRITE_ACP, last is the READ_ACP
@@ -1767,3 +1767,51 @@ func (me *CvmService) ModifyImageSharePermission(ctx context.Context, imageId, p
 	}
 	return
 }
+
+// DescribeZoneRemainingQuota returns the remaining instance quota of the zone, `found` is false when the zone has no quota entry.
+func (me *CvmService) DescribeZoneRemainingQuota(ctx context.Context, zone string, prepaid bool) (remaining int64, found bool, errRet error) {
+	logId := getLogId(ctx)
+	request := cvm.NewDescribeAccountQuotaRequest()
+	quotaType := "PostPaidQuotaSet"
+	if prepaid {
+		quotaType = "PrePaidQuotaSet"
+	}
+	request.Filters = []*cvm.Filter{
+		{Name: helper.String("zone"), Values: []*string{helper.String(zone)}},
+		{Name: helper.String("quota-type"), Values: []*string{helper.String(quotaType)}},
+	}
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+				logId, request.GetAction(), request.ToJsonString(), errRet.Error())
+		}
+	}()
+
+	ratelimit.Check(request.GetAction())
+	response, err := me.client.UseCvmClient().DescribeAccountQuota(request)
+	if err != nil {
+		errRet = err
+		return
+	}
+	if response == nil || response.Response == nil || response.Response.AccountQuotaOverview == nil ||
+		response.Response.AccountQuotaOverview.AccountQuota == nil {
+		return
+	}
+
+	quota := response.Response.AccountQuotaOverview.AccountQuota
+	if prepaid {
+		for _, item := range quota.PrePaidQuotaSet {
+			if item.Zone != nil && *item.Zone == zone && item.RemainingQuota != nil {
+				return int64(*item.RemainingQuota), true, nil
+			}
+		}
+		return
+	}
+	for _, item := range quota.PostPaidQuotaSet {
+		if item.Zone != nil && *item.Zone == zone && item.RemainingQuota != nil {
+			return int64(*item.RemainingQuota), true, nil
+		}
+	}
+	return
+}
@@ -2,9 +2,11 @@ package tencentcloud
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
 	sdkErrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
@@ -60,10 +62,10 @@ func (me *EMRService) CreateInstance(ctx context.Context, d *schema.ResourceData
 		var vpcId string
 		var subnetId string
 
-		if subV, ok := value["vpc_id"]; ok {
+		if subV, ok := value[EMR_VPC_SETTINGS_KEY_VPC_ID]; ok {
 			vpcId = subV.(string)
 		}
-		if subV, ok := value["subnet_id"]; ok {
+		if subV, ok := value[EMR_VPC_SETTINGS_KEY_SUBNET_ID]; ok {
 			subnetId = subV.(string)
 		}
 		vpcSettings := &emr.VPCSettings{VpcId: &vpcId, SubnetId: &subnetId}
@@ -81,34 +83,7 @@ func (me *EMRService) CreateInstance(ctx context.Context, d *schema.ResourceData
 	if v, ok := d.GetOk("resource_spec"); ok {
 		tmpResourceSpec := v.([]interface{})
 		resourceSpec := tmpResourceSpec[0].(map[string]interface{})
-		request.ResourceSpec = &emr.NewResourceSpec{}
-		for k, v := range resourceSpec {
-			if k == "master_resource_spec" {
-				if len(v.([]interface{})) > 0 {
-					request.ResourceSpec.MasterResourceSpec = ParseResource(v.([]interface{})[0].(map[string]interface{}))
-				}
-			} else if k == "core_resource_spec" {
-				if len(v.([]interface{})) > 0 {
-					request.ResourceSpec.CoreResourceSpec = ParseResource(v.([]interface{})[0].(map[string]interface{}))
-				}
-			} else if k == "task_resource_spec" {
-				if len(v.([]interface{})) > 0 {
-					request.ResourceSpec.TaskResourceSpec = ParseResource(v.([]interface{})[0].(map[string]interface{}))
-				}
-			} else if k == "master_count" {
-				request.ResourceSpec.MasterCount = common.Int64Ptr((int64)(v.(int)))
-			} else if k == "core_count" {
-				request.ResourceSpec.CoreCount = common.Int64Ptr((int64)(v.(int)))
-			} else if k == "task_count" {
-				request.ResourceSpec.TaskCount = common.Int64Ptr((int64)(v.(int)))
-			} else if k == "common_resource_spec" {
-				if len(v.([]interface{})) > 0 {
-					request.ResourceSpec.CommonResourceSpec = ParseResource(v.([]interface{})[0].(map[string]interface{}))
-				}
-			} else if k == "common_count" {
-				request.ResourceSpec.CommonCount = common.Int64Ptr((int64)(v.(int)))
-			}
-		}
+		request.ResourceSpec = ParseResourceSpec(resourceSpec)
 	}
 
 	if v, ok := d.GetOk("support_ha"); ok {
@@ -140,6 +115,10 @@ func (me *EMRService) CreateInstance(ctx context.Context, d *schema.ResourceData
 		}
 	}
 
+	if v, ok := d.GetOkExists("auto_renew"); ok && payMode.(int) == 1 {
+		request.AutoRenew = common.Uint64Ptr((uint64)(v.(int)))
+	}
+
 	if v, ok := d.GetOk("time_span"); ok {
 		request.TimeSpan = common.Uint64Ptr((uint64)(v.(int)))
 	}
@@ -164,23 +143,79 @@ func (me *EMRService) CreateInstance(ctx context.Context, d *schema.ResourceData
 		request.ExtendFsField = common.StringPtr(v.(string))
 	}
 
+	if metaDbInfo, ok := helper.InterfacesHeadMap(d, "meta_db_info"); ok {
+		metaType := metaDbInfo["meta_type"].(string)
+		request.MetaType = common.StringPtr(metaType)
+		switch metaType {
+		case EMR_META_TYPE_EXIT_META:
+			unifyMetaInstanceId := metaDbInfo["unify_meta_instance_id"].(string)
+			if unifyMetaInstanceId == "" {
+				err = fmt.Errorf("`unify_meta_instance_id` is required when `meta_type` is `%s`", metaType)
+				return
+			}
+			request.UnifyMetaInstanceId = common.StringPtr(unifyMetaInstanceId)
+		case EMR_META_TYPE_CUSTOM_META:
+			jdbcUrl := metaDbInfo["meta_data_jdbc_url"].(string)
+			user := metaDbInfo["meta_data_user"].(string)
+			pass := metaDbInfo["meta_data_pass"].(string)
+			if jdbcUrl == "" || user == "" || pass == "" {
+				err = fmt.Errorf("`meta_data_jdbc_url`, `meta_data_user` and `meta_data_pass` are required when `meta_type` is `%s`", metaType)
+				return
+			}
+			request.MetaDBInfo = &emr.CustomMetaInfo{
+				MetaDataJdbcUrl: common.StringPtr(jdbcUrl),
+				MetaDataUser:    common.StringPtr(user),
+				MetaDataPass:    common.StringPtr(pass),
+			}
+		}
+	}
+
+	if v, ok := d.GetOk("bootstrap_actions"); ok {
+		for _, item := range v.([]interface{}) {
+			action := item.(map[string]interface{})
+			request.PreExecutedFileSettings = append(request.PreExecutedFileSettings, &emr.PreExecuteFileSettings{
+				CosFileURI: common.StringPtr(action["path"].(string)),
+				Args:       helper.InterfacesStringsPoint(action["args"].([]interface{})),
+				RunOrder:   common.Int64Ptr(int64(action["run_order"].(int))),
+			})
+		}
+	}
+
+	if d.Get("disk_encrypt").(bool) {
+		request.CbsEncrypt = common.Uint64Ptr(1)
+	}
+
 	ratelimit.Check(request.GetAction())
 	//API: https://cloud.tencent.com/document/api/589/34261
 	response, err := me.client.UseEmrClient().CreateInstance(request)
 	if err != nil {
-		log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
-			logId, request.GetAction(), request.ToJsonString(), err.Error())
+		// the request body carries the login password and the metadb password, keep it out of logs
+		log.Printf("[CRITAL]%s api[%s] fail, reason[%s]\n",
+			logId, request.GetAction(), err.Error())
 		return
 	}
 	id = *response.Response.InstanceId
 	return
 }
 
+func (me *EMRService) InquiryPriceCreateInstance(ctx context.Context, request *emr.InquiryPriceCreateInstanceRequest) (errRet error) {
+	logId := getLogId(ctx)
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+				logId, request.GetAction(), request.ToJsonString(), errRet.Error())
+		}
+	}()
+
+	ratelimit.Check(request.GetAction())
+	_, errRet = me.client.UseEmrClient().InquiryPriceCreateInstance(request)
+	return
+}
+
 func (me *EMRService) DescribeInstances(ctx context.Context, filters map[string]interface{}) (clusters []*emr.ClusterInstancesInfo, errRet error) {
 	logId := getLogId(ctx)
 	request := emr.NewDescribeInstancesRequest()
 
-	ratelimit.Check(request.GetAction())
 	// API: https://cloud.tencent.com/document/api/589/41707
 	if v, ok := filters["instance_ids"]; ok {
 		instances := v.([]interface{})
@@ -195,23 +230,38 @@ func (me *EMRService) DescribeInstances(ctx context.Context, filters map[string]
 	if v, ok := filters["project_id"]; ok {
 		request.ProjectId = common.Int64Ptr(v.(int64))
 	}
-	response, err := me.client.UseEmrClient().DescribeInstances(request)
 
-	if err != nil {
-		if sdkError, ok := err.(*sdkErrors.TencentCloudSDKError); ok {
-			if sdkError.Code == "ResourceNotFound.ClusterNotFound" {
-				return
+	var (
+		offset uint64 = 0
+		limit  uint64 = 100
+	)
+	for {
+		request.Offset = &offset
+		request.Limit = &limit
+		ratelimit.Check(request.GetAction())
+		response, err := me.client.UseEmrClient().DescribeInstances(request)
+
+		if err != nil {
+			if sdkError, ok := err.(*sdkErrors.TencentCloudSDKError); ok {
+				if sdkError.Code == "ResourceNotFound.ClusterNotFound" {
+					return
+				}
 			}
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+				logId, request.GetAction(), request.ToJsonString(), err.Error())
+			errRet = err
+			return
 		}
-		log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
-			logId, request.GetAction(), request.ToJsonString(), err.Error())
-		errRet = err
-		return
-	}
-	log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
-		logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+			logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
 
-	clusters = response.Response.ClusterList
+		clusters = append(clusters, response.Response.ClusterList...)
+		if len(response.Response.ClusterList) < int(limit) {
+			break
+		}
+		// offset is a page number
+		offset++
+	}
 	return
 }
 
@@ -225,6 +275,8 @@ func (me *EMRService) DescribeInstancesById(ctx context.Context, instanceId stri
 	request.InstanceIds = make([]*string, 0)
 	request.InstanceIds = append(request.InstanceIds, common.StringPtr(instanceId))
 	request.DisplayStrategy = common.StringPtr(displayStrategy)
+	// cancelling the operation, e.g. by Ctrl-C, aborts the call while waiting for the cluster
+	request.SetContext(ctx)
 
 	response, err := me.client.UseEmrClient().DescribeInstances(request)
 	if err != nil {
@@ -240,6 +292,92 @@ func (me *EMRService) DescribeInstancesById(ctx context.Context, instanceId stri
 	return
 }
 
+func (me *EMRService) EmrClusterStateFunc(ctx context.Context, instanceId string, displayStrategy string) func() (string, error) {
+	return func() (string, error) {
+		var clusters []*emr.ClusterInstancesInfo
+		err := resource.RetryContext(ctx, readRetryTimeout, func() *resource.RetryError {
+			if err := ctx.Err(); err != nil {
+				return resource.NonRetryableError(err)
+			}
+			result, e := me.DescribeInstancesById(ctx, instanceId, displayStrategy)
+			if e != nil {
+				if sdkError, ok := e.(*sdkErrors.TencentCloudSDKError); ok && sdkError.GetCode() == "InternalError.ClusterNotFound" {
+					return nil
+				}
+				return resource.RetryableError(e)
+			}
+			clusters = result
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		if len(clusters) == 0 || clusters[0].Status == nil {
+			return EmrClusterStateNotFound, nil
+		}
+		return strconv.FormatInt(*clusters[0].Status, 10), nil
+	}
+}
+
+// IsMetaDbShared reports whether the metadb of the cluster must be kept when the cluster is deleted,
+// which is the case when it is configured as an external metadb or used by other clusters.
+func (me *EMRService) IsMetaDbShared(ctx context.Context, d *schema.ResourceData, instanceId, metaDb string) (shared bool, errRet error) {
+	if metaDbInfo, ok := helper.InterfacesHeadMap(d, "meta_db_info"); ok {
+		if metaDbInfo["meta_type"].(string) != EMR_META_TYPE_NEW_META {
+			shared = true
+			return
+		}
+	}
+
+	logId := getLogId(ctx)
+	request := emr.NewDescribeInstancesRequest()
+	request.ProjectId = helper.IntInt64(-1)
+	request.DisplayStrategy = common.StringPtr(DisplayStrategyIsclusterList)
+
+	var limit uint64 = 100
+	shared, errRet = emrMetaDbUsedByOtherClusters(instanceId, metaDb, limit, func(offset uint64) ([]*emr.ClusterInstancesInfo, error) {
+		request.Offset = &offset
+		request.Limit = &limit
+		ratelimit.Check(request.GetAction())
+		response, err := me.client.UseEmrClient().DescribeInstances(request)
+		if err != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+				logId, request.GetAction(), request.ToJsonString(), err.Error())
+			return nil, err
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+			logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+		return response.Response.ClusterList, nil
+	})
+	return
+}
+
+// emrMetaDbUsedByOtherClusters pages through the clusters returned by describe, whose offset is a page number,
+// and reports whether a cluster other than instanceId uses the metadb.
+func emrMetaDbUsedByOtherClusters(instanceId, metaDb string, limit uint64, describe func(offset uint64) ([]*emr.ClusterInstancesInfo, error)) (bool, error) {
+	var offset uint64 = 0
+	for {
+		clusters, err := describe(offset)
+		if err != nil {
+			return false, err
+		}
+
+		for _, cluster := range clusters {
+			if cluster.ClusterId == nil || *cluster.ClusterId == instanceId {
+				continue
+			}
+			if cluster.MetaDb != nil && *cluster.MetaDb == metaDb {
+				return true, nil
+			}
+		}
+		if len(clusters) < int(limit) {
+			break
+		}
+		offset++
+	}
+	return false, nil
+}
+
 func (me *EMRService) DescribeClusterNodes(ctx context.Context, instanceId, nodeFlag, hardwareResourceType string, offset, limit int) (nodes []*emr.NodeHardwareInfo, errRet error) {
 	logId := getLogId(ctx)
 	request := emr.NewDescribeClusterNodesRequest()
@@ -251,6 +389,7 @@ func (me *EMRService) DescribeClusterNodes(ctx context.Context, instanceId, node
 	request.HardwareResourceType = &hardwareResourceType
 	request.Limit = helper.IntInt64(limit)
 	request.Offset = helper.IntInt64(offset)
+	request.SetContext(ctx)
 	response, err := me.client.UseEmrClient().DescribeClusterNodes(request)
 
 	if err != nil {
@@ -267,3 +406,62 @@ func (me *EMRService) DescribeClusterNodes(ctx context.Context, instanceId, node
 	nodes = response.Response.NodeList
 	return
 }
+
+// DescribeTaskNodeResourceIds returns the resource ids of all the task nodes of the cluster.
+func (me *EMRService) DescribeTaskNodeResourceIds(ctx context.Context, instanceId string) (resourceIds []string, errRet error) {
+	offset := 0
+	for {
+		var nodes []*emr.NodeHardwareInfo
+		err := resource.RetryContext(ctx, readRetryTimeout, func() *resource.RetryError {
+			if err := ctx.Err(); err != nil {
+				return resource.NonRetryableError(err)
+			}
+			result, e := me.DescribeClusterNodes(ctx, instanceId, EMR_NODE_FLAG_TASK, EMR_HARDWARE_RESOURCE_TYPE_ALL, offset, EMR_DESCRIBE_CLUSTER_NODE_LIMIT)
+			if e != nil {
+				return retryError(e, InternalError)
+			}
+			nodes = result
+			return nil
+		})
+		if err != nil {
+			errRet = err
+			return
+		}
+
+		for _, node := range nodes {
+			if node.EmrResourceId != nil {
+				resourceIds = append(resourceIds, *node.EmrResourceId)
+			}
+		}
+		if len(nodes) < EMR_DESCRIBE_CLUSTER_NODE_LIMIT {
+			break
+		}
+		// offset is a page number
+		offset++
+	}
+	return
+}
+
+func (me *EMRService) TerminateTasks(ctx context.Context, instanceId string, resourceIds []string) (errRet error) {
+	logId := getLogId(ctx)
+	request := emr.NewTerminateTasksRequest()
+	request.InstanceId = &instanceId
+	request.ResourceIds = helper.Strings(resourceIds)
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+				logId, request.GetAction(), request.ToJsonString(), errRet.Error())
+		}
+	}()
+
+	ratelimit.Check(request.GetAction())
+	response, err := me.client.UseEmrClient().TerminateTasks(request)
+	if err != nil {
+		errRet = err
+		return
+	}
+	log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+		logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+	return
+}
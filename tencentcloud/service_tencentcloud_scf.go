@@ -714,12 +714,23 @@ func (me *ScfService) DeleteScfFunctionVersionById(ctx context.Context, function
 	return
 }
 
+// DescribeScfFunctionEventInvokeConfigById returns nil when the function or its namespace is not found,
+// and an empty config when the function has no async trigger config. Only transient errors are retried.
 func (me *ScfService) DescribeScfFunctionEventInvokeConfigById(ctx context.Context, namespace string, functionName string) (FunctionEventInvokeConfig *scf.AsyncTriggerConfig, errRet error) {
+	return me.DescribeScfFunctionEventInvokeConfigByQualifier(ctx, namespace, functionName, "")
+}
+
+// DescribeScfFunctionEventInvokeConfigByQualifier is DescribeScfFunctionEventInvokeConfigById of a function version or alias,
+// an empty qualifier means $LATEST.
+func (me *ScfService) DescribeScfFunctionEventInvokeConfigByQualifier(ctx context.Context, namespace, functionName, qualifier string) (FunctionEventInvokeConfig *scf.AsyncTriggerConfig, errRet error) {
 	logId := getLogId(ctx)
 
 	request := scf.NewGetFunctionEventInvokeConfigRequest()
 	request.Namespace = &namespace
 	request.FunctionName = &functionName
+	if qualifier != "" {
+		request.Qualifier = &qualifier
+	}
 
 	defer func() {
 		if errRet != nil {
@@ -727,16 +738,34 @@ func (me *ScfService) DescribeScfFunctionEventInvokeConfigById(ctx context.Conte
 		}
 	}()
 
-	ratelimit.Check(request.GetAction())
+	var response *scf.GetFunctionEventInvokeConfigResponse
+	err := resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
 
-	response, err := me.client.UseScfClient().GetFunctionEventInvokeConfig(request)
+		result, e := me.client.UseScfClient().GetFunctionEventInvokeConfig(request)
+		if e != nil {
+			if isExpectError(e, SCF_FUNCTIONS_NOT_FOUND_SET) {
+				log.Printf("[WARN]%s function [%s] of namespace [%s] not found, reason[%s]\n", logId, functionName, namespace, e.Error())
+				return nil
+			}
+			return retryError(e, InternalError)
+		}
+		response = result
+		return nil
+	})
 	if err != nil {
 		errRet = err
 		return
 	}
+	if response == nil {
+		return
+	}
 	log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
 
 	FunctionEventInvokeConfig = response.Response.AsyncTriggerConfig
+	if FunctionEventInvokeConfig == nil {
+		FunctionEventInvokeConfig = &scf.AsyncTriggerConfig{}
+	}
 	return
 }
 
@@ -358,7 +358,7 @@ func (me *SqlserverService) DescribeSqlserverInstanceById(ctx context.Context, i
 	}
 
 	instance = instanceList[0]
-	if instance != nil && *instance.Status != 8 && *instance.Status != 4 && *instance.Status != 6 {
+	if instance != nil && instance.Status != nil && *instance.Status != 8 && *instance.Status != 4 && *instance.Status != 6 {
 		has = true
 	}
 	return
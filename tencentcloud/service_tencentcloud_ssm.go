@@ -392,10 +392,13 @@ func (me *SsmService) DescribeSecretById(ctx context.Context, secretName string,
 		offset += limit
 	}
 
-	if len(instances) < 1 {
-		return
+	// the secret name is searched fuzzily, only an exact match counts
+	for _, instance := range instances {
+		if instance.SecretName != nil && *instance.SecretName == secretName {
+			sshKeyPairSecret = instance
+			return
+		}
 	}
-	sshKeyPairSecret = instances[0]
 	return
 }
 
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
 
 	tdmq "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/tdmq/v20200217"
@@ -1435,21 +1437,51 @@ func (me *TdmqService) DescribeTdmqRabbitmqUserById(ctx context.Context, instanc
 		}
 	}()
 
-	ratelimit.Check(request.GetAction())
+	rabbitmqUser, errRet = describeTdmqRabbitmqUserInCreateWindow(ctx, RabbitMQUserCreateWindow, func() ([]*tdmq.RabbitMQUser, error) {
+		ratelimit.Check(request.GetAction())
+		response, e := me.client.UseTdmqClient().DescribeRabbitMQUser(request)
+		if e != nil {
+			return nil, e
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+		return response.Response.RabbitMQUserList, nil
+	})
 
-	response, err := me.client.UseTdmqClient().DescribeRabbitMQUser(request)
-	if err != nil {
-		errRet = err
-		return
-	}
+	return
+}
 
-	log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+// describeTdmqRabbitmqUserInCreateWindow calls describe until the user is found. Right after create, which is marked by
+// justCreatedKey in ctx, the user may not be visible yet, so not-found is retried until window elapses. Otherwise
+// not-found returns a nil user at once.
+func describeTdmqRabbitmqUserInCreateWindow(ctx context.Context, window time.Duration, describe func() ([]*tdmq.RabbitMQUser, error)) (rabbitmqUser *tdmq.RabbitMQUser, errRet error) {
+	justCreated, _ := ctx.Value(justCreatedKey).(bool)
+
+	var notFound bool
+	err := resource.Retry(window, func() *resource.RetryError {
+		// an attempt failing after a not-found one must not be swallowed as not-found
+		notFound = false
+		users, e := describe()
+		if e != nil {
+			return retryError(e)
+		}
+
+		notFound = len(users) < 1
+		if notFound {
+			if justCreated {
+				return resource.RetryableError(fmt.Errorf("rabbitmq user is not found yet"))
+			}
+			return nil
+		}
 
-	if len(response.Response.RabbitMQUserList) < 1 {
-		return
+		rabbitmqUser = users[0]
+		return nil
+	})
+
+	// still not found after the window, it is a genuine not-found
+	if err != nil && !notFound {
+		errRet = err
 	}
 
-	rabbitmqUser = response.Response.RabbitMQUserList[0]
 	return
 }
 
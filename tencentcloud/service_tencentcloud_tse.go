@@ -523,13 +523,16 @@ func (me *TseService) DescribeTseGatewayRoutesByFilter(ctx context.Context, para
 		if response == nil || response.Response.Result == nil || len(response.Response.Result.RouteList) < 1 {
 			break
 		}
-		total = *response.Response.Result.TotalCount
+		if response.Response.Result.TotalCount != nil {
+			total = *response.Response.Result.TotalCount
+		}
 		route = append(route, response.Response.Result.RouteList...)
-		if len(response.Response.Result.RouteList) < int(limit) {
+		// the API may return less than limit on a page, so only TotalCount tells whether it is the last one
+		if int64(len(route)) >= total {
 			break
 		}
 
-		offset += limit
+		offset += int64(len(response.Response.Result.RouteList))
 	}
 
 	gatewayRoutes = &tse.KongServiceRouteList{
@@ -9,6 +9,7 @@ import (
 	"log"
 	"net"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -2423,6 +2424,128 @@ func (me *VpcService) DeleteEip(ctx context.Context, eipId string) error {
 	return nil
 }
 
+// CheckEipAvailable returns the EIPs of `publicIps` which can not be bound to `instanceId`, keyed by public IP with the reason.
+// EIPs already bound to `instanceId` are available, so updates can pass the whole EIP set, creations pass an empty `instanceId`.
+func (me *VpcService) CheckEipAvailable(ctx context.Context, publicIps []string, instanceId string) (conflicts map[string]string, errRet error) {
+	conflicts = make(map[string]string)
+	if len(publicIps) == 0 {
+		return
+	}
+
+	var eips []*vpc.Address
+	errRet = resource.RetryContext(ctx, readRetryTimeout, func() *resource.RetryError {
+		if err := ctx.Err(); err != nil {
+			return resource.NonRetryableError(err)
+		}
+		result, e := me.DescribeEipByFilter(ctx, map[string][]string{"address-ip": publicIps})
+		if e != nil {
+			return retryError(e)
+		}
+		eips = result
+		return nil
+	})
+	if errRet != nil {
+		return
+	}
+
+	found := make(map[string]*vpc.Address, len(eips))
+	for _, eip := range eips {
+		if eip.AddressIp != nil {
+			found[*eip.AddressIp] = eip
+		}
+	}
+	for _, ip := range publicIps {
+		eip, ok := found[ip]
+		if !ok {
+			conflicts[ip] = "it is not an EIP of the account in this region"
+			continue
+		}
+		if eip.InstanceId != nil && *eip.InstanceId != "" {
+			if *eip.InstanceId != instanceId {
+				conflicts[ip] = fmt.Sprintf("it is bound to %s", *eip.InstanceId)
+			}
+			continue
+		}
+		if eip.AddressStatus != nil && *eip.AddressStatus != EIP_STATUS_UNBIND {
+			conflicts[ip] = fmt.Sprintf("its status is %s", *eip.AddressStatus)
+		}
+	}
+	return
+}
+
+// eipConflictsError formats the conflicts returned by CheckEipAvailable, nil if there is none.
+func eipConflictsError(conflicts map[string]string) error {
+	if len(conflicts) == 0 {
+		return nil
+	}
+	ips := make([]string, 0, len(conflicts))
+	for ip := range conflicts {
+		ips = append(ips, ip)
+	}
+	sort.Strings(ips)
+	messages := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		messages = append(messages, fmt.Sprintf("EIP %s can not be bound, %s", ip, conflicts[ip]))
+	}
+	return fmt.Errorf("%s", strings.Join(messages, "; "))
+}
+
+// ReleaseUnboundEipsByPublicIp releases the EIPs of the public IPs, EIPs which are bound again are skipped.
+func (me *VpcService) ReleaseUnboundEipsByPublicIp(ctx context.Context, publicIps []string) error {
+	logId := getLogId(ctx)
+	if len(publicIps) == 0 {
+		return nil
+	}
+
+	var eips []*vpc.Address
+	err := resource.RetryContext(ctx, readRetryTimeout, func() *resource.RetryError {
+		if err := ctx.Err(); err != nil {
+			return resource.NonRetryableError(err)
+		}
+		result, e := me.DescribeEipByFilter(ctx, map[string][]string{"address-ip": publicIps})
+		if e != nil {
+			return retryError(e)
+		}
+		for _, eip := range result {
+			if eip.AddressStatus != nil && *eip.AddressStatus == EIP_STATUS_UNBINDING {
+				return resource.RetryableError(fmt.Errorf("EIP %s is still unbinding", *eip.AddressId))
+			}
+		}
+		eips = result
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, eip := range eips {
+		if eip.AddressId == nil {
+			continue
+		}
+		eipId := *eip.AddressId
+		if eip.AddressStatus != nil && *eip.AddressStatus != EIP_STATUS_UNBIND {
+			log.Printf("[WARN]%s skip releasing EIP [%s], its status is %s\n", logId, eipId, *eip.AddressStatus)
+			continue
+		}
+
+		err := resource.RetryContext(ctx, writeRetryTimeout, func() *resource.RetryError {
+			if err := ctx.Err(); err != nil {
+				return resource.NonRetryableError(err)
+			}
+			e := me.DeleteEip(ctx, eipId)
+			if e != nil {
+				return retryError(e, "DesOperation.MutexTaskRunning")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (me *VpcService) AttachEip(ctx context.Context, eipId, instanceId string) error {
 	logId := getLogId(ctx)
 	request := vpc.NewAssociateAddressRequest()
@@ -2446,6 +2569,8 @@ func (me *VpcService) DescribeNatGatewayById(ctx context.Context, natGateWayId s
 	logId := getLogId(ctx)
 	request := vpc.NewDescribeNatGatewaysRequest()
 	request.NatGatewayIds = []*string{&natGateWayId}
+	// cancelling the operation, e.g. by Ctrl-C, aborts the call while waiting for the gateway
+	request.SetContext(ctx)
 	defer func() {
 		if errRet != nil {
 			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
@@ -2471,6 +2596,104 @@ func (me *VpcService) DescribeNatGatewayById(ctx context.Context, natGateWayId s
 	return
 }
 
+func (me *VpcService) NatGatewayStateFunc(ctx context.Context, natGatewayId string) func() (string, error) {
+	return func() (string, error) {
+		var nat *vpc.NatGateway
+		err := resource.RetryContext(ctx, readRetryTimeout, func() *resource.RetryError {
+			if err := ctx.Err(); err != nil {
+				return resource.NonRetryableError(err)
+			}
+			result, e := me.DescribeNatGatewayById(ctx, natGatewayId)
+			if e != nil {
+				return retryError(e)
+			}
+			nat = result
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		if nat == nil || nat.State == nil {
+			return NAT_NOT_FOUND_STATE, nil
+		}
+		return *nat.State, nil
+	}
+}
+
+// WaitForNatGatewayEips polls until the NAT gateway is available and its EIPs are exactly the expected ones.
+func (me *VpcService) WaitForNatGatewayEips(ctx context.Context, natGatewayId string, expectedIps []string, timeout time.Duration) error {
+	return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		if err := ctx.Err(); err != nil {
+			return resource.NonRetryableError(err)
+		}
+		nat, e := me.DescribeNatGatewayById(ctx, natGatewayId)
+		if e != nil {
+			return retryError(e)
+		}
+		if nat == nil {
+			return resource.NonRetryableError(fmt.Errorf("NAT gateway %s not found", natGatewayId))
+		}
+		if nat.State == nil || *nat.State != NAT_AVAILABLE_STATE {
+			return resource.RetryableError(fmt.Errorf("NAT gateway %s is still updating EIPs", natGatewayId))
+		}
+
+		currentIps := make([]string, 0, len(nat.PublicIpAddressSet))
+		for _, address := range nat.PublicIpAddressSet {
+			if address.PublicIpAddress != nil {
+				currentIps = append(currentIps, *address.PublicIpAddress)
+			}
+		}
+		if len(currentIps) != len(expectedIps) {
+			return resource.RetryableError(fmt.Errorf("NAT gateway %s EIPs are %v, waiting for %v", natGatewayId, currentIps, expectedIps))
+		}
+		for _, ip := range expectedIps {
+			if !IsContains(currentIps, ip) {
+				return resource.RetryableError(fmt.Errorf("NAT gateway %s EIPs are %v, waiting for %v", natGatewayId, currentIps, expectedIps))
+			}
+		}
+		return nil
+	})
+}
+
+// ModifyNatGatewaySecurityGroups replaces the security groups bound to a standard NAT gateway,
+// an empty list unbinds all of them.
+func (me *VpcService) ModifyNatGatewaySecurityGroups(ctx context.Context, natGatewayId string, securityGroupIds []*string) (errRet error) {
+	logId := getLogId(ctx)
+
+	natGateway, err := me.DescribeNatGatewayById(ctx, natGatewayId)
+	if err != nil {
+		return err
+	}
+	if natGateway == nil {
+		return fmt.Errorf("NAT gateway %s not found", natGatewayId)
+	}
+	if natGateway.NatProductVersion == nil || *natGateway.NatProductVersion != NAT_PRODUCT_VERSION_STANDARD {
+		if len(securityGroupIds) == 0 {
+			return nil
+		}
+		return fmt.Errorf("`security_group_ids` is only supported by the standard NAT gateway")
+	}
+
+	request := vpc.NewModifyNatGatewayAttributeRequest()
+	request.NatGatewayId = &natGatewayId
+	request.ModifySecurityGroup = helper.Bool(true)
+	request.SecurityGroupIds = securityGroupIds
+
+	errRet = resource.Retry(writeRetryTimeout, func() *resource.RetryError {
+		ratelimit.Check(request.GetAction())
+		response, e := me.client.UseVpcClient().ModifyNatGatewayAttribute(request)
+		if e != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n",
+				logId, request.GetAction(), request.ToJsonString(), e.Error())
+			return retryError(e)
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n",
+			logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+		return nil
+	})
+	return
+}
+
 func (me *VpcService) DescribeNatGatewayByFilter(ctx context.Context, filters map[string]string) (instances []*vpc.NatGateway, errRet error) {
 	var (
 		logId   = getLogId(ctx)
@@ -7232,6 +7455,77 @@ func (me *VpcService) DescribeVpcGatewayFlowMonitorDetailByFilter(ctx context.Co
 	return
 }
 
+// DescribeNatGatewayEips returns the EIPs bound to the NAT gateway.
+func (me *VpcService) DescribeNatGatewayEips(ctx context.Context, nat *vpc.NatGateway) (eips []*vpc.Address, errRet error) {
+	publicIps := make([]string, 0, len(nat.PublicIpAddressSet))
+	for _, address := range nat.PublicIpAddressSet {
+		if address.PublicIpAddress != nil {
+			publicIps = append(publicIps, *address.PublicIpAddress)
+		}
+	}
+	if len(publicIps) == 0 {
+		return
+	}
+
+	errRet = resource.Retry(readRetryTimeout, func() *resource.RetryError {
+		result, e := me.DescribeEipByFilter(ctx, map[string][]string{"address-ip": publicIps})
+		if e != nil {
+			return retryError(e)
+		}
+		eips = result
+		return nil
+	})
+	return
+}
+
+// natGatewayIsp returns the ISP line of the NAT gateway, which is the one of its EIPs.
+func natGatewayIsp(eips []*vpc.Address) string {
+	for _, eip := range eips {
+		if eip.InternetServiceProvider != nil && *eip.InternetServiceProvider != "" {
+			return *eip.InternetServiceProvider
+		}
+	}
+	return NAT_DEFAULT_ISP
+}
+
+// DescribeNatGatewayPeakOutBandwidth returns the peak outbound bandwidth (Mbps) of the NAT gateway
+// within the last `minutes` minutes, calculated from the per-minute flow monitor details.
+func (me *VpcService) DescribeNatGatewayPeakOutBandwidth(ctx context.Context, natGatewayId string, minutes int) (peak float64, errRet error) {
+	location, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		errRet = err
+		return
+	}
+	now := time.Now().In(location)
+
+	for i := 1; i <= minutes; i++ {
+		timePoint := now.Add(-time.Duration(i) * time.Minute).Format(TENCENTCLOUD_COMMON_TIME_LAYOUT)
+		param := map[string]interface{}{
+			"TimePoint": helper.String(timePoint),
+			"NatId":     helper.String(natGatewayId),
+		}
+		details, err := me.DescribeVpcGatewayFlowMonitorDetailByFilter(ctx, param)
+		if err != nil {
+			errRet = err
+			return
+		}
+
+		var outTraffic uint64
+		for _, detail := range details {
+			if detail.OutTraffic != nil {
+				outTraffic += *detail.OutTraffic
+			}
+		}
+		// bytes per minute to Mbps
+		bandwidth := float64(outTraffic) * 8 / 60 / 1000 / 1000
+		if bandwidth > peak {
+			peak = bandwidth
+		}
+	}
+
+	return
+}
+
 func (me *VpcService) DescribeVpcGatewayFlowQosByFilter(ctx context.Context, param map[string]interface{}) (GatewayFlowQos []*vpc.GatewayQos, errRet error) {
 	var (
 		logId   = getLogId(ctx)
@@ -14,11 +14,14 @@ type WedataService struct {
 	client *connectivity.TencentCloudClient
 }
 
-func (me *WedataService) DescribeWedataRuleTemplateById(ctx context.Context, ruleTemplateId string) (ruleTemplate *wedata.RuleTemplate, errRet error) {
+func (me *WedataService) DescribeWedataRuleTemplateById(ctx context.Context, projectId, ruleTemplateId string) (ruleTemplate *wedata.RuleTemplate, errRet error) {
 	logId := getLogId(ctx)
 
 	request := wedata.NewDescribeRuleTemplateRequest()
 	request.TemplateId = helper.StrToUint64Point(ruleTemplateId)
+	if projectId != "" {
+		request.ProjectId = helper.String(projectId)
+	}
 
 	defer func() {
 		if errRet != nil {
@@ -66,3 +69,51 @@ func (me *WedataService) DeleteWedataRuleTemplateById(ctx context.Context, ruleT
 
 	return
 }
+
+func (me *WedataService) DescribeWedataRuleTemplatesByFilter(ctx context.Context, param map[string]interface{}) (ruleTemplates []*wedata.RuleTemplate, errRet error) {
+	var (
+		logId   = getLogId(ctx)
+		request = wedata.NewDescribeRuleTemplatesByPageRequest()
+	)
+
+	defer func() {
+		if errRet != nil {
+			log.Printf("[CRITAL]%s api[%s] fail, request body [%s], reason[%s]\n", logId, request.GetAction(), request.ToJsonString(), errRet.Error())
+		}
+	}()
+
+	for k, v := range param {
+		if k == "ProjectId" {
+			request.ProjectId = v.(*string)
+		}
+	}
+
+	var (
+		pageNumber uint64 = 1
+		pageSize   uint64 = 20
+	)
+	for {
+		request.PageNumber = &pageNumber
+		request.PageSize = &pageSize
+
+		ratelimit.Check(request.GetAction())
+		response, err := me.client.UseWedataClient().DescribeRuleTemplatesByPage(request)
+		if err != nil {
+			errRet = err
+			return
+		}
+		log.Printf("[DEBUG]%s api[%s] success, request body [%s], response body [%s]\n", logId, request.GetAction(), request.ToJsonString(), response.ToJsonString())
+
+		if response == nil || response.Response.Data == nil || len(response.Response.Data.Items) < 1 {
+			break
+		}
+		ruleTemplates = append(ruleTemplates, response.Response.Data.Items...)
+		if len(response.Response.Data.Items) < int(pageSize) {
+			break
+		}
+
+		pageNumber++
+	}
+
+	return
+}